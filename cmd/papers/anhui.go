@@ -3,7 +3,6 @@ package papers
 import (
 	"fmt"
 	"os"
-	"papers/internal/anhui"
 	"papers/internal/crawler"
 	"strings"
 
@@ -37,13 +36,23 @@ var anhuiCmd = &cobra.Command{
   papers anhui -p ahrb,ncb,xawb
 
   # 下载指定日期的指定报纸
-  papers anhui -d 2025-11-10 -p ahrb,ncb`,
+  papers anhui -d 2025-11-10 -p ahrb,ncb
+
+  # 图片版面按手机阅读质量压缩
+  papers anhui -p xawb --quality mobile
+
+  # 下载最近已发布的一期（今天未发布或非出版日时向前查找）
+  papers anhui -p ahrb --latest
+
+  # 只下载前4版
+  papers anhui -p ahrb --pages 1-4`,
 	Run: runanhuiCrawler,
 }
 
 var (
 	anhuiDateStr   string
 	anhuiPaperType string
+	anhuiOptions   downloadOptions
 )
 
 func init() {
@@ -51,17 +60,24 @@ func init() {
 	anhuiCmd.Flags().StringVarP(&anhuiDateStr, "date", "d", "", "指定日期，格式: YYYY-MM-DD (例: 2025-11-10)，默认为当天")
 	anhuiCmd.Flags().StringVarP(&anhuiPaperType, "paper", "p", "", "报纸类型，多个用逗号分隔 (例: ahrb,ncb,xawb)，默认下载所有")
 
+	anhuiOptions.addFlags(anhuiCmd)
+
 	rootCmd.AddCommand(anhuiCmd)
 }
 
 func runanhuiCrawler(cmd *cobra.Command, args []string) {
 	fmt.Println("=== 安徽日报系列PDF爬虫 ===")
 
+	if err := anhuiOptions.parse(); err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+
 	// 解析报纸类型
 	var anhuiPaperTypes []string
 	if anhuiPaperType == "" {
 		// 如果没有指定，下载所有类型
-		anhuiPaperTypes = []string{"ahrb", "ncb", "jhsb", "fzb", "pc", "xawb"}
+		anhuiPaperTypes = paperCodes(crawler.FamilyPapers("anhui"))
 		fmt.Println("未指定报纸类型，将下载所有报纸")
 	} else {
 		// 按逗号分隔
@@ -73,6 +89,13 @@ func runanhuiCrawler(cmd *cobra.Command, args []string) {
 		fmt.Printf("指定报纸类型: %s\n", strings.Join(anhuiPaperTypes, ", "))
 	}
 
+	// 解析日期
+	date, err := crawler.ParseDate(anhuiDateStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+
 	// 显示日期信息
 	if anhuiDateStr != "" {
 		fmt.Printf("使用指定日期: %s\n", anhuiDateStr)
@@ -81,89 +104,19 @@ func runanhuiCrawler(cmd *cobra.Command, args []string) {
 	}
 	fmt.Println()
 
-	// 记录成功和失败的数量
-	successCount := 0
+	// 从注册表查找报纸并创建爬虫实例
+	var crawlers []*crawler.Crawler
 	failCount := 0
-
-	// 遍历所有报纸类型
 	for _, pt := range anhuiPaperTypes {
-		fmt.Printf("=== 开始爬取 %s ===\n", getAnhuiPaperName(pt))
-
-		// 创建对应的Fetcher
-		var fetcher crawler.PaperFetcher
-		var c *crawler.Crawler
-		var err error
-
-		// 先创建爬虫实例获取日期
-		tempCrawler, err := anhui.NewCrawler(pt, nil, anhuiDateStr)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "创建爬虫失败 (%s): %v\n", pt, err)
-			failCount++
-			fmt.Println()
-			continue
-		}
-
-		// 根据报纸类型创建对应的Fetcher
-		switch pt {
-		case "ahrb":
-			fetcher = anhui.NewAHRBFetcher(tempCrawler.GetDate())
-		case "ncb":
-			fetcher = anhui.NewNCBFetcher(tempCrawler.GetDate())
-		case "jhsb":
-			fetcher = anhui.NewJHSBFetcher(tempCrawler.GetDate())
-		case "fzb":
-			fetcher = anhui.NewFZBFetcher(tempCrawler.GetDate())
-		case "pc":
-			fetcher = anhui.NewPCFetcher(tempCrawler.GetDate())
-		case "xawb":
-			// XAWB需要outputDir来存储临时文件
-			fetcher = anhui.NewXAWBFetcher(tempCrawler.GetDate(), "web/files")
-		default:
+		paper, ok := crawler.LookupPaper(pt)
+		if !ok || paper.Family != "anhui" {
 			fmt.Fprintf(os.Stderr, "未知的报纸类型: %s\n", pt)
 			failCount++
-			fmt.Println()
 			continue
 		}
-
-		// 重新创建带Fetcher的爬虫实例
-		c, err = anhui.NewCrawler(pt, fetcher, anhuiDateStr)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "创建爬虫失败 (%s): %v\n", pt, err)
-			failCount++
-			fmt.Println()
-			continue
-		}
-
-		fmt.Printf("爬取日期: %s (东8区时间)\n", c.GetDateString())
-
-		// 执行爬虫任务
-		if err := c.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "爬取失败 (%s): %v\n", pt, err)
-			failCount++
-		} else {
-			fmt.Printf("✓ %s 爬取完成!\n", getAnhuiPaperName(pt))
-			successCount++
-		}
-		fmt.Println()
+		crawlers = append(crawlers, paper.NewCrawler(date))
 	}
 
-	// 显示总结
-	fmt.Println("==================")
-	fmt.Printf("任务完成! 成功: %d, 失败: %d\n", successCount, failCount)
-}
-
-// getAnhuiPaperName 获取报纸的中文名称
-func getAnhuiPaperName(anhuiPaperType string) string {
-	names := map[string]string{
-		"ahrb": "安徽日报",
-		"ncb":  "安徽日报农村版",
-		"jhsb": "江淮时报",
-		"fzb":  "安徽法治报",
-		"pc":   "安徽商报",
-		"xawb": "新安晚报",
-	}
-	if name, ok := names[anhuiPaperType]; ok {
-		return name
-	}
-	return anhuiPaperType
+	// 执行爬虫任务并显示总结
+	anhuiOptions.run(crawlers, failCount)
 }
@@ -0,0 +1,108 @@
+package papers
+
+import (
+	"papers/internal/crawler"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// archiveFileName dist 下的文件名: 前缀_日期.pdf 或 前缀_日期_后缀.pdf
+// 前缀为报纸代码时，无后缀的是合并文件，带后缀的是部分版面、打印版、手机阅读版等派生文件；
+// 前缀不是报纸代码时（papers_、frontpages_）为多份报纸的合集
+var archiveFileName = regexp.MustCompile(`^([a-z0-9]+)_(\d{8})(?:_(.+))?\.pdf$`)
+
+// archivedFile dist 下的一期报纸
+type archivedFile struct {
+	paper string
+	date  string // 2006-01-02
+	path  string
+}
+
+// archivedEdition dist 下同一期报纸或同一天的合集的所有文件
+type archivedEdition struct {
+	archivedFile          // 合并文件，只有派生文件时 path 为空
+	extra        []string // 派生文件
+	collection   bool     // 多份报纸的合集，paper 为文件名前缀
+}
+
+// paths 返回这一期的所有文件
+func (e archivedEdition) paths() []string {
+	if e.path == "" {
+		return e.extra
+	}
+	return append([]string{e.path}, e.extra...)
+}
+
+// archivedEditions 列出 dist 下的所有文件，按报纸和日期归类，按日期、报纸排序
+func archivedEditions(dir string) ([]archivedEdition, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*", "*.pdf"))
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]*archivedEdition)
+	var editions []*archivedEdition
+	for _, path := range paths {
+		m := archiveFileName.FindStringSubmatch(filepath.Base(path))
+		// 只处理与所在日期目录一致的文件
+		if m == nil || m[2] != filepath.Base(filepath.Dir(path)) {
+			continue
+		}
+		date, err := time.Parse("20060102", m[2])
+		if err != nil {
+			continue
+		}
+
+		key := m[1] + "/" + m[2]
+		e, ok := index[key]
+		if !ok {
+			_, registered := crawler.LookupPaper(m[1])
+			e = &archivedEdition{
+				archivedFile: archivedFile{paper: m[1], date: date.Format("2006-01-02")},
+				collection:   !registered,
+			}
+			index[key] = e
+			editions = append(editions, e)
+		}
+		if m[3] == "" {
+			e.path = path
+		} else {
+			e.extra = append(e.extra, path)
+		}
+	}
+
+	result := make([]archivedEdition, 0, len(editions))
+	for _, e := range editions {
+		result = append(result, *e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].date != result[j].date {
+			return result[i].date < result[j].date
+		}
+		return result[i].paper < result[j].paper
+	})
+	return result, nil
+}
+
+// archivedFiles 列出 dist 下日期范围内各报纸的合并文件，按日期、报纸排序
+// 派生文件和合集不包含在内
+func archivedFiles(dir, from, to string) ([]archivedFile, error) {
+	editions, err := archivedEditions(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []archivedFile
+	for _, e := range editions {
+		if e.collection || e.path == "" {
+			continue
+		}
+		if (from != "" && e.date < from) || (to != "" && e.date > to) {
+			continue
+		}
+		files = append(files, e.archivedFile)
+	}
+	return files, nil
+}
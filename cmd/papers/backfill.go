@@ -0,0 +1,151 @@
+package papers
+
+import (
+	"fmt"
+	"os"
+	"papers/internal/catalog"
+	"papers/internal/crawler"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "检测缺失的报纸并补下载",
+	Long: `对照各报纸的出版规则，找出指定日期范围内缺失或不完整的报纸并补下载
+
+已下载的报纸以下载目录 (dist/catalog.jsonl，可用 --catalog 指定) 为准；目录中没有记录但
+dist 下存在合并文件的报纸（如启用目录前下载的）也视为已下载，
+按保留策略清理过的报纸不会补下载。
+补下载按日期先后依次进行，每期之间等待 --delay 指定的时间，避免请求过于频繁。
+
+示例:
+  # 只列出 2025 年以来缺失的报纸，不下载
+  papers backfill -p ahrb,rmrb --since 2025-01-01 --dry-run
+
+  # 补下载缺失的报纸，每期间隔 1 分钟
+  papers backfill -p ahrb,rmrb --since 2025-01-01 --delay 1m`,
+	Run: runBackfill,
+}
+
+var (
+	backfillPaperType string
+	backfillSince     string
+	backfillUntil     string
+	backfillDryRun    bool
+	backfillDelay     time.Duration
+	backfillOptions   downloadOptions
+)
+
+func init() {
+	backfillCmd.Flags().StringVarP(&backfillPaperType, "paper", "p", "", "报纸类型，多个用逗号分隔 (例: ahrb,rmrb)，默认检查所有")
+	backfillCmd.Flags().StringVar(&backfillSince, "since", "", "起始日期（含），格式: YYYY-MM-DD")
+	backfillCmd.Flags().StringVar(&backfillUntil, "until", "", "结束日期（含），格式: YYYY-MM-DD，默认为当天")
+	backfillCmd.Flags().BoolVar(&backfillDryRun, "dry-run", false, "只列出缺失的报纸，不下载")
+	backfillCmd.Flags().DurationVar(&backfillDelay, "delay", 30*time.Second, "两期报纸下载之间的等待时间")
+	backfillOptions.addQualityFlags(backfillCmd)
+	backfillCmd.MarkFlagRequired("since")
+
+	rootCmd.AddCommand(backfillCmd)
+}
+
+// gap 一期缺失或不完整的报纸
+type gap struct {
+	paper  *crawler.Paper
+	date   time.Time
+	reason string
+}
+
+func runBackfill(cmd *cobra.Command, args []string) {
+	papers, err := resolvePapers(backfillPaperType)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+
+	since, err := crawler.ParseDate(backfillSince)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+	until, err := crawler.ParseDate(backfillUntil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+	if since.After(until) {
+		fmt.Fprintf(os.Stderr, "参数错误: 起始日期晚于结束日期\n")
+		os.Exit(1)
+	}
+
+	if err := backfillOptions.parse(); err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+
+	cat, err := catalog.Load(catalogPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("=== 检查 %s 至 %s 的报纸 ===\n", since.Format("2006-01-02"), until.Format("2006-01-02"))
+	gaps := findGaps(cat, papers, since, until)
+	if len(gaps) == 0 {
+		fmt.Println("没有缺失的报纸")
+		return
+	}
+
+	for _, g := range gaps {
+		fmt.Printf("- %s %s: %s\n", g.date.Format("2006-01-02"), g.paper.Name, g.reason)
+	}
+	fmt.Printf("共 %d 期缺失或不完整\n\n", len(gaps))
+
+	if backfillDryRun {
+		return
+	}
+
+	summary := &downloadSummary{}
+	for i, g := range gaps {
+		if i > 0 && backfillDelay > 0 {
+			fmt.Printf("等待 %s 后继续...\n\n", backfillDelay)
+			time.Sleep(backfillDelay)
+		}
+		backfillOptions.runOne(g.paper.NewCrawler(g.date), summary)
+	}
+	summary.print()
+
+	if summary.fail > 0 {
+		os.Exit(1)
+	}
+}
+
+// findGaps 按日期先后列出各报纸在出版日缺失或不完整的期次
+func findGaps(cat *catalog.Catalog, papers []*crawler.Paper, since, until time.Time) []gap {
+	var gaps []gap
+	last := until.Format("2006-01-02")
+	for date := since; date.Format("2006-01-02") <= last; date = date.AddDate(0, 0, 1) {
+		day := date.Format("2006-01-02")
+		for _, p := range papers {
+			if !p.PublishesOn(date) {
+				continue
+			}
+
+			if e, ok := cat.Lookup(p.Code, day); ok {
+				// 按保留策略清理过的报纸不再补下载
+				if e.Pruned == "" && !e.Complete() {
+					gaps = append(gaps, gap{paper: p, date: date, reason: fmt.Sprintf("不完整，缺第 %s 版", joinPages(e.MissingPages))})
+				}
+				continue
+			}
+
+			// 目录中没有记录时检查合并文件是否存在
+			if _, err := os.Stat(p.NewCrawler(date).MergedFilePath()); err == nil {
+				continue
+			}
+			gaps = append(gaps, gap{paper: p, date: date, reason: "缺失"})
+		}
+	}
+	return gaps
+}
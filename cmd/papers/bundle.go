@@ -0,0 +1,95 @@
+package papers
+
+import (
+	"fmt"
+	"os"
+	"papers/internal/crawler"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "将同一天的所有报纸合并为一个PDF",
+	Long: `将 dist/YYYYMMDD/ 下已下载的报纸按注册顺序合并为 papers_YYYYMMDD.pdf
+
+合集中每份报纸一个书签，各版面的书签嵌套在其下；阅读器中的页码显示为
+"报纸名称 版次"。使用 --dividers 在每份报纸前插入显示报纸名称的分隔页，
+分隔页需要在配置文件中指定中文字体 (font)。
+
+示例:
+  # 合并今天已下载的所有报纸
+  papers bundle
+
+  # 合并指定日期的部分报纸，并插入分隔页
+  papers bundle -d 2025-11-10 -p rmrb,jksb,ahrb --dividers`,
+	Run: runBundle,
+}
+
+var (
+	bundleDateStr   string
+	bundlePaperType string
+	bundleDividers  bool
+)
+
+func init() {
+	bundleCmd.Flags().StringVarP(&bundleDateStr, "date", "d", "", "指定日期，格式: YYYY-MM-DD (例: 2025-11-10)，默认为当天")
+	bundleCmd.Flags().StringVarP(&bundlePaperType, "paper", "p", "", "报纸类型，多个用逗号分隔 (例: rmrb,ahrb)，默认合并所有已下载的报纸")
+	bundleCmd.Flags().BoolVar(&bundleDividers, "dividers", false, "在每份报纸前插入分隔页")
+
+	rootCmd.AddCommand(bundleCmd)
+}
+
+func runBundle(cmd *cobra.Command, args []string) {
+	papers, err := resolvePapers(bundlePaperType)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+	date, err := crawler.ParseDate(bundleDateStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg := loadConfig()
+	if bundleDividers && cfg.Font == "" {
+		fmt.Fprintf(os.Stderr, "参数错误: --dividers 需要在配置文件中指定支持中文的字体 (font)\n")
+		os.Exit(1)
+	}
+
+	fmt.Printf("=== 合并 %s 的报纸 ===\n", date.Format("2006-01-02"))
+
+	// 按注册顺序收集已下载的报纸
+	var editions []crawler.Edition
+	var dir string
+	for _, p := range papers {
+		path := p.NewCrawler(date).MergedFilePath()
+		dir = filepath.Dir(path)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		fmt.Printf("- %s: %s\n", p.Name, path)
+		editions = append(editions, crawler.Edition{Paper: p.Code, Path: path})
+	}
+	if len(editions) == 0 {
+		fmt.Fprintf(os.Stderr, "%s 下没有已下载的报纸\n", dir)
+		os.Exit(1)
+	}
+
+	out := filepath.Join(dir, fmt.Sprintf("papers_%s.pdf", date.Format("20060102")))
+	opts := crawler.BundleOptions{Date: date, Dividers: bundleDividers, Font: cfg.Font}
+	if err := crawler.Bundle(editions, out, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "合并失败: %v\n", err)
+		os.Exit(1)
+	}
+
+	info, err := os.Stat(out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("==================")
+	fmt.Printf("已将 %d 份报纸合并至 %s (%s)\n", len(editions), out, formatBytes(info.Size()))
+}
@@ -0,0 +1,127 @@
+package papers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"papers/internal/catalog"
+	"papers/internal/crawler"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "查询已下载报纸的目录",
+	Long: `查询已下载报纸的目录
+
+每次下载合并完成后，会在 dist/catalog.jsonl（可用 --catalog 指定）中追加一条记录，包括版数、
+缺失的版面、文件大小、sha256、各版面的来源地址和下载时间。
+同一期重新下载时以最新的记录为准。
+
+示例:
+  # 列出所有已下载的报纸
+  papers catalog
+
+  # 查询指定报纸在某段时间内的记录
+  papers catalog -p rmrb,xawb --from 2025-11-01 --to 2025-11-30
+
+  # 以 JSON Lines 格式输出，便于其他工具处理
+  papers catalog -p rmrb --json`,
+	Run: runCatalog,
+}
+
+var (
+	catalogPaperType string
+	catalogFrom      string
+	catalogTo        string
+	catalogJSON      bool
+)
+
+func init() {
+	catalogCmd.Flags().StringVarP(&catalogPaperType, "paper", "p", "", "报纸类型，多个用逗号分隔 (例: rmrb,xawb)，默认查询所有")
+	catalogCmd.Flags().StringVar(&catalogFrom, "from", "", "起始日期（含），格式: YYYY-MM-DD")
+	catalogCmd.Flags().StringVar(&catalogTo, "to", "", "结束日期（含），格式: YYYY-MM-DD")
+	catalogCmd.Flags().BoolVar(&catalogJSON, "json", false, "以 JSON Lines 格式输出")
+
+	rootCmd.AddCommand(catalogCmd)
+}
+
+func runCatalog(cmd *cobra.Command, args []string) {
+	q := catalog.Query{From: catalogFrom, To: catalogTo}
+	for _, d := range []string{catalogFrom, catalogTo} {
+		if d == "" {
+			continue
+		}
+		if _, err := crawler.ParseDate(d); err != nil {
+			fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	for _, code := range strings.Split(catalogPaperType, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			q.Papers = append(q.Papers, code)
+		}
+	}
+
+	cat, err := catalog.Load(catalogPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	entries := cat.Query(q)
+
+	if catalogJSON {
+		enc := json.NewEncoder(os.Stdout)
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				fmt.Fprintf(os.Stderr, "输出失败: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		return
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("没有符合条件的记录")
+		return
+	}
+
+	var total int64
+	incomplete := 0
+	for _, e := range entries {
+		status := "✓"
+		pages := fmt.Sprintf("%d 版", e.PageCount)
+		switch {
+		case e.Pruned == catalog.PrunedDeleted:
+			status = "-"
+			pages += "，已清理"
+		case e.Pruned == catalog.PrunedFrontPage:
+			pages += "，仅保留头版"
+		case !e.Complete():
+			status = "!"
+			pages = fmt.Sprintf("%d/%d 版，缺 %s", e.PageCount-len(e.MissingPages), e.PageCount, joinPages(e.MissingPages))
+			incomplete++
+		}
+		fmt.Printf("%s %s %-6s %s, %s, sha256 %.12s, 下载于 %s\n",
+			status, e.Date, e.Paper, pages, formatBytes(e.Size), e.SHA256, e.FetchedAt.Format("2006-01-02 15:04"))
+		total += e.Size
+	}
+
+	fmt.Println("==================")
+	fmt.Printf("共 %d 期, %s", len(entries), formatBytes(total))
+	if incomplete > 0 {
+		fmt.Printf(", 不完整 %d 期", incomplete)
+	}
+	fmt.Println()
+}
+
+// joinPages 将版面列表格式化为逗号分隔的字符串
+func joinPages(pages []int) string {
+	parts := make([]string, len(pages))
+	for i, p := range pages {
+		parts[i] = fmt.Sprint(p)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,119 @@
+package papers
+
+import (
+	"fmt"
+	"os"
+	"papers/internal/crawler"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// check 命令的退出码，全部发布时为0，便于脚本判断
+const (
+	checkExitUnavailable = 1 // 至少一份报纸未发布
+	checkExitUsage       = 2 // 参数错误
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "检查报纸是否已发布（不下载）",
+	Long: `检查指定日期的报纸是否已发布，报告版数和PDF总大小
+
+只请求版面页面并通过HEAD请求获取文件大小，不下载任何PDF。
+
+退出码:
+  0  所有报纸均已发布
+  1  至少一份报纸未发布
+  2  参数错误
+
+示例:
+  # 检查今天所有报纸
+  papers check
+
+  # 检查指定日期的指定报纸
+  papers check -p rmrb,xawb -d 2025-11-10`,
+	Run: runCheck,
+}
+
+var (
+	checkDateStr        string
+	checkPaperType      string
+	checkIgnoreSchedule bool
+)
+
+func init() {
+	checkCmd.Flags().StringVarP(&checkDateStr, "date", "d", "", "指定日期，格式: YYYY-MM-DD (例: 2025-11-10)，默认为当天")
+	checkCmd.Flags().StringVarP(&checkPaperType, "paper", "p", "", "报纸类型，多个用逗号分隔 (例: rmrb,xawb)，默认检查所有")
+
+	checkCmd.Flags().BoolVar(&checkIgnoreSchedule, "ignore-schedule", false, "忽略报纸的出版规则，非出版日也检查")
+
+	rootCmd.AddCommand(checkCmd)
+}
+
+func runCheck(cmd *cobra.Command, args []string) {
+	papers, err := resolvePapers(checkPaperType)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(checkExitUsage)
+	}
+
+	date, err := crawler.ParseDate(checkDateStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(checkExitUsage)
+	}
+
+	fmt.Printf("=== 检查 %s 的报纸 ===\n", date.Format("2006-01-02"))
+
+	history := loadPublishHistory(loadConfig())
+
+	unavailable := 0
+	skipped := 0
+	results := make([]*crawler.CheckResult, len(papers))
+	for i, p := range papers {
+		// 非出版日不检查，也不计为未发布
+		if !checkIgnoreSchedule && !p.PublishesOn(date) {
+			skipped++
+			continue
+		}
+		fmt.Printf("检查 %s ...\n", p.Name)
+		results[i] = p.NewCrawler(date).Check()
+		if !results[i].Available {
+			unavailable++
+			history.missing(p.Code, date, time.Now())
+		} else {
+			history.observe(p.Code, date)
+		}
+	}
+
+	// 显示结果
+	fmt.Println("==================")
+	for i, p := range papers {
+		r := results[i]
+		expected := ""
+		if e := history.expected(p.Code, date); e != "" {
+			expected = ", 预计发布时间 " + e
+		}
+		if r == nil {
+			fmt.Printf("- %-6s %s: 未排期 (出版规则: %s)\n", p.Code, p.Name, p.Schedule)
+			continue
+		}
+		if !r.Available {
+			fmt.Printf("✗ %-6s %s: 未发布 (%v)%s\n", p.Code, p.Name, r.Err, expected)
+			continue
+		}
+
+		size := formatBytes(r.TotalSize)
+		if r.UnknownSizes > 0 {
+			size += fmt.Sprintf("（%d 版大小未知）", r.UnknownSizes)
+		}
+		fmt.Printf("✓ %-6s %s: 已发布, %d 版, 找到 %d 个资源, 共 %s%s\n",
+			p.Code, p.Name, r.PageCount, r.AssetCount, size, expected)
+	}
+	fmt.Printf("已发布: %d, 未发布: %d, 未排期: %d\n", len(papers)-unavailable-skipped, unavailable, skipped)
+
+	if unavailable > 0 {
+		os.Exit(checkExitUnavailable)
+	}
+}
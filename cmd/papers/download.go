@@ -0,0 +1,143 @@
+package papers
+
+import (
+	"fmt"
+	"os"
+	"papers/internal/crawler"
+)
+
+// downloadSummary 下载任务的统计
+type downloadSummary struct {
+	success    int
+	fail       int
+	skipped    int // 非出版日跳过的报纸
+	bytesSaved int64
+}
+
+// run 执行爬虫任务并显示总结
+// 设置了 --wait-until 时各报纸独立轮询，先发布的报纸先下载，不会被发布慢的报纸拖住
+func (o *downloadOptions) run(crawlers []*crawler.Crawler, failCount int) {
+	summary := &downloadSummary{fail: failCount}
+
+	if o.latest {
+		crawlers = o.resolveLatest(crawlers, summary)
+	} else {
+		// 跳过非出版日的报纸
+		scheduled := make([]*crawler.Crawler, 0, len(crawlers))
+		for _, c := range crawlers {
+			if o.scheduled(c, summary) {
+				scheduled = append(scheduled, c)
+			}
+		}
+		crawlers = scheduled
+	}
+
+	if o.wait == nil {
+		for _, c := range crawlers {
+			o.runOne(c, summary)
+		}
+	} else {
+		fmt.Printf("等待报纸发布，截止时间: %s\n\n", o.wait.Deadline.Format("15:04"))
+
+		type waitResult struct {
+			c   *crawler.Crawler
+			err error
+		}
+		results := make(chan waitResult, len(crawlers))
+		for _, c := range crawlers {
+			if expected := o.history.expected(c.PaperType, c.Date); expected != "" {
+				fmt.Printf("%s 预计发布时间: %s\n", crawler.PaperName(c.PaperType), expected)
+			}
+		}
+		for _, c := range crawlers {
+			go func(c *crawler.Crawler) {
+				results <- waitResult{c: c, err: c.WaitForPublication(*o.wait)}
+			}(c)
+		}
+
+		// 下载按发布先后依次进行
+		for range crawlers {
+			r := <-results
+			if r.err != nil {
+				fmt.Fprintf(os.Stderr, "放弃等待 (%s): %v\n\n", r.c.PaperType, r.err)
+				summary.fail++
+				continue
+			}
+			// 发布时间介于最后一次未发布和轮询到发布的时刻之间
+			o.history.missing(r.c.PaperType, r.c.Date, r.c.LastMissing)
+			o.history.observe(r.c.PaperType, r.c.Date)
+			o.runOne(r.c, summary)
+		}
+	}
+
+	summary.print()
+
+	if summary.success > 0 {
+		autoPrune(o.config)
+	}
+}
+
+// print 显示总结
+func (s *downloadSummary) print() {
+	fmt.Println("==================")
+	fmt.Printf("任务完成! 成功: %d, 失败: %d\n", s.success, s.fail)
+	if s.skipped > 0 {
+		fmt.Printf("未排期: %d（非出版日，可用 --ignore-schedule 强制下载）\n", s.skipped)
+	}
+	if s.bytesSaved != 0 {
+		fmt.Printf("图片压缩节省: %s\n", formatBytes(s.bytesSaved))
+	}
+}
+
+// scheduled 判断报纸在爬取日期是否出版，非出版日记为未排期
+func (o *downloadOptions) scheduled(c *crawler.Crawler, summary *downloadSummary) bool {
+	if o.ignoreSchedule || crawler.PaperScheduled(c.PaperType, c.Date) {
+		return true
+	}
+	fmt.Printf("- %s: 未排期（%s 不出版）\n\n", crawler.PaperName(c.PaperType), c.GetDateString())
+	summary.skipped++
+	return false
+}
+
+// resolveLatest 将各报纸替换为最近已发布一期的爬虫，找不到时计为失败
+func (o *downloadOptions) resolveLatest(crawlers []*crawler.Crawler, summary *downloadSummary) []*crawler.Crawler {
+	resolved := make([]*crawler.Crawler, 0, len(crawlers))
+	for _, c := range crawlers {
+		paper, ok := crawler.LookupPaper(c.PaperType)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "报纸 %s 未注册，不支持 --latest\n\n", c.PaperType)
+			summary.fail++
+			continue
+		}
+
+		latest, err := paper.FindLatest(c.Date, o.latestDays, o.ignoreSchedule)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "查找最新一期失败 (%s): %v\n\n", c.PaperType, err)
+			summary.fail++
+			continue
+		}
+		fmt.Printf("%s 最新一期: %s\n\n", paper.Name, latest.GetDateString())
+		resolved = append(resolved, latest)
+	}
+	return resolved
+}
+
+// runOne 执行单份报纸的爬虫任务
+func (o *downloadOptions) runOne(c *crawler.Crawler, summary *downloadSummary) {
+	pt := c.PaperType
+	fmt.Printf("=== 开始爬取 %s ===\n", crawler.PaperName(pt))
+	fmt.Printf("爬取日期: %s (东8区时间)\n", c.GetDateString())
+	o.apply(c)
+
+	// 执行爬虫任务
+	if err := c.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "爬取失败 (%s): %v\n", pt, err)
+		summary.fail++
+	} else {
+		fmt.Printf("✓ %s 爬取完成!\n", crawler.PaperName(pt))
+		summary.success++
+		o.history.observe(pt, c.Date)
+	}
+	summary.bytesSaved += c.BytesSaved
+	fmt.Println()
+}
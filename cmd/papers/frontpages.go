@@ -0,0 +1,99 @@
+package papers
+
+import (
+	"fmt"
+	"os"
+	"papers/internal/crawler"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var frontpagesCmd = &cobra.Command{
+	Use:   "frontpages",
+	Short: "下载所有报纸的头版并合并为今日头版",
+	Long: `只下载每份报纸的第1版，按注册顺序合并为 dist/YYYYMMDD/frontpages_YYYYMMDD.pdf
+
+每份报纸的头版一个书签，阅读器中的页码显示为报纸名称。
+非出版日的报纸会跳过，单份报纸下载失败不影响其他报纸。
+
+示例:
+  # 今天所有报纸的头版
+  papers frontpages
+
+  # 指定日期和报纸
+  papers frontpages -d 2025-11-10 -p rmrb,ahrb,xawb`,
+	Run: runFrontpages,
+}
+
+var (
+	frontpagesDateStr   string
+	frontpagesPaperType string
+	frontpagesOptions   downloadOptions
+)
+
+func init() {
+	frontpagesCmd.Flags().StringVarP(&frontpagesDateStr, "date", "d", "", "指定日期，格式: YYYY-MM-DD (例: 2025-11-10)，默认为当天")
+	frontpagesCmd.Flags().StringVarP(&frontpagesPaperType, "paper", "p", "", "报纸类型，多个用逗号分隔 (例: rmrb,ahrb)，默认为所有已注册的报纸")
+	frontpagesOptions.addQualityFlags(frontpagesCmd)
+	frontpagesOptions.addScheduleFlags(frontpagesCmd)
+
+	rootCmd.AddCommand(frontpagesCmd)
+}
+
+func runFrontpages(cmd *cobra.Command, args []string) {
+	papers, err := resolvePapers(frontpagesPaperType)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+	date, err := crawler.ParseDate(frontpagesDateStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+
+	frontpagesOptions.pages = "1"
+	if err := frontpagesOptions.parse(); err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+
+	// 各报纸的头版单独下载后合并，合并完成后删除
+	summary := &downloadSummary{}
+	var editions []crawler.Edition
+	var dir string
+	for _, p := range papers {
+		c := p.NewCrawler(date)
+		dir = c.MergedDir
+		if !frontpagesOptions.scheduled(c, summary) {
+			continue
+		}
+		success := summary.success
+		frontpagesOptions.runOne(c, summary)
+		if summary.success > success {
+			editions = append(editions, crawler.Edition{Paper: p.Code, Path: c.MergedFilePath()})
+		}
+	}
+	summary.print()
+
+	if len(editions) == 0 {
+		fmt.Fprintln(os.Stderr, "没有下载到任何头版")
+		os.Exit(1)
+	}
+
+	out := filepath.Join(dir, fmt.Sprintf("frontpages_%s.pdf", date.Format("20060102")))
+	opts := crawler.BundleOptions{Title: "今日头版", Date: date}
+	if err := crawler.Bundle(editions, out, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "合并头版失败: %v\n", err)
+		os.Exit(1)
+	}
+	for _, e := range editions {
+		os.Remove(e.Path)
+	}
+
+	fmt.Printf("今日头版: %d 份报纸，保存至 %s\n", len(editions), out)
+	if summary.fail > 0 {
+		os.Exit(1)
+	}
+}
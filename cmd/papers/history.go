@@ -0,0 +1,57 @@
+package papers
+
+import (
+	"fmt"
+	"os"
+	"papers/internal/config"
+	"papers/internal/history"
+	"time"
+)
+
+// publishHistory 报纸发布时间记录及其文件路径
+type publishHistory struct {
+	path    string
+	history *history.History
+}
+
+// loadPublishHistory 读取状态目录下的发布时间记录，读取失败时只给出警告并使用空记录
+func loadPublishHistory(cfg *config.Config) *publishHistory {
+	ph := &publishHistory{path: cfg.StatePath("publish_times.json")}
+
+	h, err := history.Load(ph.path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "警告: %v\n", err)
+		h = history.New()
+	}
+	ph.history = h
+	return ph
+}
+
+// observe 记录报纸在当前时刻已经可用
+func (ph *publishHistory) observe(code string, edition time.Time) {
+	if !ph.history.Observe(code, edition, time.Now()) {
+		return
+	}
+	if err := ph.history.Save(ph.path); err != nil {
+		fmt.Fprintf(os.Stderr, "警告: 保存发布时间记录失败: %v\n", err)
+	}
+}
+
+// missing 记录报纸在 at 时刻尚未发布，at 为零值时不记录
+func (ph *publishHistory) missing(code string, edition, at time.Time) {
+	if at.IsZero() || !ph.history.ObserveMissing(code, edition, at) {
+		return
+	}
+	if err := ph.history.Save(ph.path); err != nil {
+		fmt.Fprintf(os.Stderr, "警告: 保存发布时间记录失败: %v\n", err)
+	}
+}
+
+// expected 返回报纸在指定日期的预计发布时间描述，没有记录时返回空字符串
+func (ph *publishHistory) expected(code string, date time.Time) string {
+	profile, ok := ph.history.Expected(code, date.Weekday())
+	if !ok {
+		return ""
+	}
+	return profile.String()
+}
@@ -0,0 +1,96 @@
+package papers
+
+import (
+	"fmt"
+	"os"
+	"papers/internal/crawler"
+
+	"github.com/spf13/cobra"
+)
+
+var imposeCmd = &cobra.Command{
+	Use:   "impose [文件...]",
+	Short: "为已下载的报纸生成打印版",
+	Long: `将合并后的PDF按打印排版生成新文件，页面缩放到A4或A3纸张
+
+排版方式 (--layout):
+  • 2up      每张纸2版，横向纸张左右排列
+  • 4up      每张纸4版，纵向纸张2x2排列
+  • booklet  小册子，沿短边翻转双面打印后从中间对折，页数较多时分帖
+
+可在排版方式后指定纸张，如 booklet:A3，默认为A4。
+生成的文件与原文件在同一目录，如 fcyym_20251110_booklet_A4.pdf。
+未指定文件时，处理 dist 下指定日期和报纸的合并文件。
+
+示例:
+  # 讽刺与幽默按A4小册子排版
+  papers impose -d 2025-11-07 -p fcyym --layout booklet
+
+  # 指定文件，每张A3纸4版
+  papers impose dist/20251110/jksb_20251110.pdf --layout 4up:A3`,
+	Run: runImpose,
+}
+
+var (
+	imposeDateStr   string
+	imposePaperType string
+	imposeLayout    string
+)
+
+func init() {
+	imposeCmd.Flags().StringVarP(&imposeDateStr, "date", "d", "", "指定日期，格式: YYYY-MM-DD (例: 2025-11-10)，默认为当天")
+	imposeCmd.Flags().StringVarP(&imposePaperType, "paper", "p", "", "报纸类型，多个用逗号分隔 (例: fcyym,jksb)，默认为所有已下载的报纸")
+	imposeCmd.Flags().StringVar(&imposeLayout, "layout", "", "打印排版: 2up、4up、booklet，可指定纸张如 booklet:A3")
+	imposeCmd.MarkFlagRequired("layout")
+
+	rootCmd.AddCommand(imposeCmd)
+}
+
+func runImpose(cmd *cobra.Command, args []string) {
+	layout, err := crawler.ParsePrintLayout(imposeLayout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+
+	files := args
+	if len(files) == 0 {
+		papers, err := resolvePapers(imposePaperType)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+			os.Exit(1)
+		}
+		date, err := crawler.ParseDate(imposeDateStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+			os.Exit(1)
+		}
+		for _, p := range papers {
+			path := p.NewCrawler(date).MergedFilePath()
+			if _, err := os.Stat(path); err == nil {
+				files = append(files, path)
+			}
+		}
+		if len(files) == 0 {
+			fmt.Fprintf(os.Stderr, "%s 没有已下载的报纸\n", date.Format("2006-01-02"))
+			os.Exit(1)
+		}
+	}
+
+	fail := 0
+	for _, path := range files {
+		out, err := crawler.Impose(path, *layout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "✗ %s: %v\n", path, err)
+			fail++
+			continue
+		}
+		fmt.Printf("✓ %s -> %s\n", path, out)
+	}
+
+	fmt.Println("==================")
+	fmt.Printf("排版完成! 成功: %d, 失败: %d\n", len(files)-fail, fail)
+	if fail > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,97 @@
+package papers
+
+import (
+	"fmt"
+	"os"
+	"papers/internal/crawler"
+
+	"github.com/spf13/cobra"
+)
+
+var mobileCmd = &cobra.Command{
+	Use:   "mobile [文件...]",
+	Short: "为已下载的报纸生成手机阅读版",
+	Long: `将合并后的PDF每个版面切分为多块，生成适合手机和电子书阅读器的 _mobile.pdf
+
+各块按中文报纸的阅读顺序排列：从右到左逐列，每列从上到下，相邻块之间
+略有重叠。切分只设置页面的可见区域，不会重复存储版面图片。
+
+切分方式 (--layout):
+  • grid     2x2 网格 (默认)
+  • strips   3列竖条，适合竖排的版面
+  • 列数x行数  如 2x3、4x1，行列数最多为6
+
+生成的文件与原文件在同一目录，如 rmrb_20251110_mobile.pdf。
+未指定文件时，处理 dist 下指定日期和报纸的合并文件。
+
+示例:
+  # 今天已下载的所有报纸切分为2x2网格
+  papers mobile
+
+  # 指定文件，切分为3列竖条
+  papers mobile dist/20251110/rmrb_20251110.pdf --layout strips`,
+	Run: runMobile,
+}
+
+var (
+	mobileDateStr   string
+	mobilePaperType string
+	mobileLayout    string
+)
+
+func init() {
+	mobileCmd.Flags().StringVarP(&mobileDateStr, "date", "d", "", "指定日期，格式: YYYY-MM-DD (例: 2025-11-10)，默认为当天")
+	mobileCmd.Flags().StringVarP(&mobilePaperType, "paper", "p", "", "报纸类型，多个用逗号分隔 (例: rmrb,ahrb)，默认为所有已下载的报纸")
+	mobileCmd.Flags().StringVar(&mobileLayout, "layout", "grid", "切分方式: grid (2x2)、strips (3列竖条) 或 列数x行数")
+
+	rootCmd.AddCommand(mobileCmd)
+}
+
+func runMobile(cmd *cobra.Command, args []string) {
+	layout, err := crawler.ParseMobileLayout(mobileLayout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+
+	files := args
+	if len(files) == 0 {
+		papers, err := resolvePapers(mobilePaperType)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+			os.Exit(1)
+		}
+		date, err := crawler.ParseDate(mobileDateStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+			os.Exit(1)
+		}
+		for _, p := range papers {
+			path := p.NewCrawler(date).MergedFilePath()
+			if _, err := os.Stat(path); err == nil {
+				files = append(files, path)
+			}
+		}
+		if len(files) == 0 {
+			fmt.Fprintf(os.Stderr, "%s 没有已下载的报纸\n", date.Format("2006-01-02"))
+			os.Exit(1)
+		}
+	}
+
+	fail := 0
+	for _, path := range files {
+		out, err := crawler.Mobile(path, *layout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "✗ %s: %v\n", path, err)
+			fail++
+			continue
+		}
+		fmt.Printf("✓ %s -> %s\n", path, out)
+	}
+
+	fmt.Println("==================")
+	fmt.Printf("切分完成! 成功: %d, 失败: %d\n", len(files)-fail, fail)
+	if fail > 0 {
+		os.Exit(1)
+	}
+}
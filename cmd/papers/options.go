@@ -0,0 +1,230 @@
+package papers
+
+import (
+	"fmt"
+	"papers/internal/config"
+	"papers/internal/crawler"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// downloadOptions 下载命令共享的参数
+type downloadOptions struct {
+	quality   string
+	grayscale bool
+
+	ignoreSchedule bool
+
+	waitUntil       string
+	pollInterval    time.Duration
+	pollMaxInterval time.Duration
+
+	latest     bool
+	latestDays int
+
+	cover bool
+
+	pages    string
+	sections string
+
+	printLayout string
+	mobile      string
+
+	preset  crawler.QualityPreset
+	wait    *crawler.WaitOptions // 未设置 --wait-until 时为 nil
+	history *publishHistory
+	config  *config.Config
+
+	selection *crawler.PageSelection // 未设置 --pages、--sections 时为 nil
+	layout    *crawler.PrintLayout   // 未设置 --print-layout 时为 nil
+	tiles     *crawler.MobileLayout  // 未设置 --mobile 时为 nil
+}
+
+// addFlags 为下载命令注册共享参数
+func (o *downloadOptions) addFlags(cmd *cobra.Command) {
+	o.addQualityFlags(cmd)
+	o.addScheduleFlags(cmd)
+	cmd.Flags().StringVar(&o.waitUntil, "wait-until", "", "等待报纸发布直到指定时间，格式: HH:MM (东8区，例: 10:00)")
+	cmd.Flags().DurationVar(&o.pollInterval, "poll-interval", 5*time.Minute, "等待发布时的初始轮询间隔")
+	cmd.Flags().DurationVar(&o.pollMaxInterval, "poll-max-interval", 20*time.Minute, "等待发布时退避后的最大轮询间隔")
+	cmd.Flags().BoolVar(&o.latest, "latest", false, "下载最近已发布的一期，从今天（或 -d 指定的日期）开始向前查找")
+	cmd.Flags().IntVar(&o.latestDays, "latest-days", 7, "--latest 最多向前查找的天数")
+	cmd.Flags().StringVar(&o.pages, "pages", "", "只下载指定的版面，如 1-4,12")
+	cmd.Flags().StringVar(&o.sections, "sections", "", "只下载名称包含指定文字的版面，多个用逗号分隔 (例: 要闻,评论)")
+	cmd.Flags().StringVar(&o.printLayout, "print-layout", "", "另外生成打印版: 2up、4up、booklet，可指定纸张如 booklet:A3 (默认A4)")
+	cmd.Flags().StringVar(&o.mobile, "mobile", "", "另外生成手机阅读版，每个版面切分为多块: grid (2x2)、strips (3列竖条) 或 列数x行数")
+	cmd.Flags().BoolVar(&o.cover, "cover", false, "在合并后的PDF前插入封面和可点击的目录，需在配置文件中指定中文字体 (font)")
+}
+
+// addQualityFlags 注册图片版面的质量参数，供只使用部分下载参数的命令复用
+func (o *downloadOptions) addQualityFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.quality, "quality", "archive", fmt.Sprintf("图片版面的质量预设 (%s)", strings.Join(crawler.QualityPresetNames(), ", ")))
+	cmd.Flags().BoolVar(&o.grayscale, "grayscale", false, "图片版面转换为灰度，适合打印")
+}
+
+// addScheduleFlags 注册出版规则相关的参数
+func (o *downloadOptions) addScheduleFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.ignoreSchedule, "ignore-schedule", false, "忽略报纸的出版规则，非出版日也尝试下载")
+}
+
+// parse 校验参数
+func (o *downloadOptions) parse() error {
+	preset, err := crawler.ParseQualityPreset(o.quality)
+	if err != nil {
+		return err
+	}
+	if o.grayscale {
+		preset.Gray = true
+	}
+	o.preset = preset
+
+	selection, err := crawler.ParsePageSelection(o.pages, o.sections)
+	if err != nil {
+		return err
+	}
+	o.selection = selection
+
+	if o.printLayout != "" {
+		layout, err := crawler.ParsePrintLayout(o.printLayout)
+		if err != nil {
+			return err
+		}
+		o.layout = layout
+	}
+
+	if o.mobile != "" {
+		tiles, err := crawler.ParseMobileLayout(o.mobile)
+		if err != nil {
+			return err
+		}
+		o.tiles = tiles
+	}
+
+	cfg, err := readConfig()
+	if err != nil {
+		return err
+	}
+	o.config = cfg
+	o.history = loadPublishHistory(cfg)
+
+	for code, pc := range o.config.Papers {
+		if pc.PaperSize == "" {
+			continue
+		}
+		if err := crawler.ValidatePaperSize(pc.PaperSize); err != nil {
+			return fmt.Errorf("配置文件中 %s 的 paper_size 无效: %v", code, err)
+		}
+	}
+
+	if o.cover && o.config.Font == "" {
+		return fmt.Errorf("--cover 需要在配置文件中指定支持中文的字体 (font)")
+	}
+
+	if o.latest {
+		if o.waitUntil != "" {
+			return fmt.Errorf("--latest 不能与 --wait-until 同时使用")
+		}
+		if o.latestDays < 0 {
+			return fmt.Errorf("--latest-days 不能为负数")
+		}
+	}
+
+	if o.waitUntil != "" {
+		deadline, err := parseClock(o.waitUntil)
+		if err != nil {
+			return err
+		}
+		o.wait = &crawler.WaitOptions{
+			Deadline:    deadline,
+			Interval:    o.pollInterval,
+			MaxInterval: o.pollMaxInterval,
+		}
+	}
+	return nil
+}
+
+// parseClock 将 HH:MM 解析为今天（东8区）的对应时刻
+func parseClock(s string) (time.Time, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("时间格式错误，应为 HH:MM 格式: %v", err)
+	}
+	loc, _ := time.LoadLocation("Asia/Shanghai")
+	now := time.Now().In(loc)
+	return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, loc), nil
+}
+
+// apply 将参数应用到爬虫实例
+func (o *downloadOptions) apply(c *crawler.Crawler) {
+	c.Quality = o.preset
+
+	family := ""
+	if p, ok := crawler.LookupPaper(c.PaperType); ok {
+		family = p.Family
+	}
+	c.Stamp = o.config.StampFor(c.PaperType, family)
+	c.Cover = o.cover
+	c.Selection = o.selection
+	c.PrintLayout = o.layout
+	c.MobileLayout = o.tiles
+	c.CoverFont = o.config.Font
+	c.CatalogPath = catalogPath
+
+	if size := o.config.Paper(c.PaperType).PaperSize; size != "" {
+		c.ImageOptions.PaperSize = size
+	}
+}
+
+// paperCodes 返回报纸代码列表
+func paperCodes(papers []*crawler.Paper) []string {
+	codes := make([]string, 0, len(papers))
+	for _, p := range papers {
+		codes = append(codes, p.Code)
+	}
+	return codes
+}
+
+// resolvePapers 解析逗号分隔的报纸代码，按注册顺序返回并去重，为空时返回所有已注册的报纸
+func resolvePapers(codes string) ([]*crawler.Paper, error) {
+	if strings.TrimSpace(codes) == "" {
+		return crawler.Papers(), nil
+	}
+
+	requested := make(map[string]bool)
+	for _, code := range strings.Split(codes, ",") {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+		if _, ok := crawler.LookupPaper(code); !ok {
+			return nil, fmt.Errorf("未知的报纸类型: %s", code)
+		}
+		requested[code] = true
+	}
+
+	var papers []*crawler.Paper
+	for _, p := range crawler.Papers() {
+		if requested[p.Code] {
+			papers = append(papers, p)
+		}
+	}
+	return papers, nil
+}
+
+// formatBytes 将字节数格式化为便于阅读的字符串
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit && n > -unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value := float64(n)
+	suffixes := []string{"KB", "MB", "GB", "TB"}
+	i := -1
+	for (value >= unit || value <= -unit) && i < len(suffixes)-1 {
+		value /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", value, suffixes[i])
+}
@@ -0,0 +1,56 @@
+package papers
+
+import (
+	"testing"
+
+	"papers/internal/crawler"
+)
+
+func TestResolvePapers(t *testing.T) {
+	registered := crawler.Papers()
+
+	tests := []struct {
+		name    string
+		codes   string
+		want    []string // nil 表示所有已注册的报纸
+		wantErr bool
+	}{
+		{name: "为空时返回所有报纸", codes: " "},
+		{name: "单份报纸", codes: "rmrb", want: []string{"rmrb"}},
+		{name: "按注册顺序", codes: "jksb,rmrb,ahrb", want: []string{"ahrb", "rmrb", "jksb"}},
+		{name: "去重", codes: "jksb, rmrb,,jksb", want: []string{"rmrb", "jksb"}},
+		{name: "未知的报纸", codes: "rmrb,nope", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			papers, err := resolvePapers(tt.codes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolvePapers(%q) error = %v, wantErr %v", tt.codes, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			want := tt.want
+			if want == nil {
+				for _, p := range registered {
+					want = append(want, p.Code)
+				}
+			}
+
+			var got []string
+			for _, p := range papers {
+				got = append(got, p.Code)
+			}
+			if len(got) != len(want) {
+				t.Fatalf("resolvePapers(%q) = %v, want %v", tt.codes, got, want)
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Fatalf("resolvePapers(%q) = %v, want %v", tt.codes, got, want)
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package papers
 import (
 	"fmt"
 	"os"
+	"papers/internal/crawler"
 	"papers/internal/people"
 	"strings"
 
@@ -34,13 +35,23 @@ var peopleCmd = &cobra.Command{
   papers people -p rmrb,jksb
 
   # 下载指定日期的指定报纸
-  papers people -d 2025-11-10 -p rmrb,jksb`,
+  papers people -d 2025-11-10 -p rmrb,jksb
+
+  # 等待报纸发布，10:00 前仍未发布则放弃
+  papers people -p rmrb --wait-until 10:00
+
+  # 下载最近已发布的一期（今天未发布或非出版日时向前查找）
+  papers people -p jksb --latest
+
+  # 只下载头版和评论版
+  papers people -p rmrb --pages 1 --sections 评论`,
 	Run: runPeopleCrawler,
 }
 
 var (
 	peopleDateStr   string
 	peoplePaperType string
+	peopleOptions   downloadOptions
 )
 
 func init() {
@@ -48,17 +59,24 @@ func init() {
 	peopleCmd.Flags().StringVarP(&peopleDateStr, "date", "d", "", "指定日期，格式: YYYY-MM-DD (例: 2025-11-10)，默认为当天")
 	peopleCmd.Flags().StringVarP(&peoplePaperType, "paper", "p", "", "报纸类型，多个用逗号分隔 (例: rmrb,jksb)，默认下载所有")
 
+	peopleOptions.addFlags(peopleCmd)
+
 	rootCmd.AddCommand(peopleCmd)
 }
 
 func runPeopleCrawler(cmd *cobra.Command, args []string) {
 	fmt.Println("=== 人民日报系列PDF爬虫 ===")
 
+	if err := peopleOptions.parse(); err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+
 	// 解析报纸类型
 	var peoplePaperTypes []string
 	if peoplePaperType == "" {
 		// 如果没有指定，下载所有类型
-		peoplePaperTypes = []string{"rmrb", "jksb", "zgcsb", "fcyym"}
+		peoplePaperTypes = paperCodes(crawler.FamilyPapers("people"))
 		fmt.Println("未指定报纸类型，将下载所有报纸")
 	} else {
 		// 按逗号分隔
@@ -78,51 +96,19 @@ func runPeopleCrawler(cmd *cobra.Command, args []string) {
 	}
 	fmt.Println()
 
-	// 记录成功和失败的数量
-	successCount := 0
+	// 创建所有爬虫实例
+	var crawlers []*crawler.Crawler
 	failCount := 0
-
-	// 遍历所有报纸类型
 	for _, pt := range peoplePaperTypes {
-		fmt.Printf("=== 开始爬取 %s ===\n", getPeoplePaperName(pt))
-
-		// 创建爬虫实例
 		c, err := people.NewCrawler(pt, peopleDateStr)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "创建爬虫失败 (%s): %v\n", pt, err)
 			failCount++
-			fmt.Println()
 			continue
 		}
-
-		fmt.Printf("爬取日期: %s (东8区时间)\n", c.GetDateString())
-
-		// 执行爬虫任务
-		if err := c.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "爬取失败 (%s): %v\n", pt, err)
-			failCount++
-		} else {
-			fmt.Printf("✓ %s 爬取完成!\n", getPeoplePaperName(pt))
-			successCount++
-		}
-		fmt.Println()
+		crawlers = append(crawlers, c)
 	}
 
-	// 显示总结
-	fmt.Println("==================")
-	fmt.Printf("任务完成! 成功: %d, 失败: %d\n", successCount, failCount)
-}
-
-// getPeoplePaperName 获取报纸的中文名称
-func getPeoplePaperName(peoplePaperType string) string {
-	names := map[string]string{
-		"rmrb":  "人民日报",
-		"jksb":  "健康时报",
-		"zgcsb": "中国城市报",
-		"fcyym": "讽刺与幽默",
-	}
-	if name, ok := names[peoplePaperType]; ok {
-		return name
-	}
-	return peoplePaperType
+	// 执行爬虫任务并显示总结
+	peopleOptions.run(crawlers, failCount)
 }
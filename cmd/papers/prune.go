@@ -0,0 +1,280 @@
+package papers
+
+import (
+	"fmt"
+	"os"
+	"papers/internal/catalog"
+	"papers/internal/config"
+	"papers/internal/crawler"
+	"papers/internal/retention"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "按保留策略清理本地归档",
+	Long: `按配置文件中的保留策略清理 dist 下的报纸
+
+保留策略在配置文件（默认 papers.json）的 retention 中配置:
+
+  {
+    "retention": {
+      "auto": true,
+      "pins": ["rmrb/2025-01-01", "fcyym"],
+      "rules": [
+        {"papers": ["xawb"], "keep_days": 30},
+        {"family": "people", "weekdays_after_days": 90, "weekdays": ["mon"], "front_page_after_days": 365},
+        {"max_size": "20GB"}
+      ]
+    }
+  }
+
+每份报纸使用第一条匹配的规则（papers、family 都为空的规则适用于所有报纸）:
+  • keep_days              只保留最近N天
+  • weekdays_after_days    N天后只保留 weekdays 中星期的报纸（默认周一）
+  • front_page_after_days  N天后只保留头版（跳过 --cover 插入的封面和目录）
+  • max_size               总大小上限，超出时从最旧的报纸开始删除
+
+部分版面、打印版（impose）、手机阅读版（mobile）等派生文件随报纸一起计算大小、
+一起清理；只保留头版时派生文件被删除。当天合集（bundle、frontpages）只匹配
+papers、family 都为空的规则，当天的报纸全部删除后合集也一并删除。
+
+pins 中的报纸永不清理，格式为 报纸/日期 或 报纸代码。
+auto 为 true 时，每次下载完成后自动清理。清理结果记录在下载目录中，
+清理过的报纸不会被 backfill 补下载。
+
+示例:
+  # 只列出将被清理的报纸
+  papers prune --dry-run
+
+  # 执行清理
+  papers prune`,
+	Run: runPrune,
+}
+
+var pruneDryRun bool
+
+func init() {
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "只列出将被清理的报纸，不删除")
+
+	rootCmd.AddCommand(pruneCmd)
+}
+
+func runPrune(cmd *cobra.Command, args []string) {
+	if err := prune(loadConfig(), pruneDryRun); err != nil {
+		fmt.Fprintf(os.Stderr, "清理失败: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// autoPrune 配置了自动清理时，在下载完成后按保留策略清理
+func autoPrune(cfg *config.Config) {
+	if !cfg.Retention.Auto {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("=== 按保留策略自动清理 ===")
+	if err := prune(cfg, false); err != nil {
+		fmt.Fprintf(os.Stderr, "自动清理失败: %v\n", err)
+	}
+}
+
+// prune 按保留策略清理 dist 下的报纸
+func prune(cfg *config.Config, dryRun bool) error {
+	if len(cfg.Retention.Rules) == 0 {
+		fmt.Println("配置文件中没有保留规则，不清理")
+		return nil
+	}
+
+	policy, err := retention.NewPolicy(cfg.Retention)
+	if err != nil {
+		return err
+	}
+
+	cat, err := catalog.Load(catalogPath)
+	if err != nil {
+		return err
+	}
+
+	editions, err := archivedEditions("dist")
+	if err != nil {
+		return fmt.Errorf("读取 dist 目录失败: %v", err)
+	}
+
+	files := make([]retention.File, 0, len(editions))
+	for _, a := range editions {
+		date, err := crawler.ParseDate(a.date)
+		if err != nil {
+			continue
+		}
+		f := retention.File{Paper: a.paper, Date: date, Path: a.path, Extra: a.extra, Collection: a.collection}
+		for _, path := range a.paths() {
+			if info, err := os.Stat(path); err == nil {
+				f.Size += info.Size()
+			}
+		}
+		if p, ok := crawler.LookupPaper(a.paper); ok {
+			f.Family = p.Family
+		}
+		if e, ok := cat.Lookup(a.paper, a.date); ok && e.Pruned == catalog.PrunedFrontPage {
+			f.FrontPage = true
+		}
+		files = append(files, f)
+	}
+
+	today, _ := crawler.ParseDate("")
+	decisions := policy.Plan(files, today)
+	if len(decisions) == 0 {
+		fmt.Println("没有需要清理的报纸")
+		return nil
+	}
+
+	var freed int64
+	cleaned := 0
+	for _, d := range decisions {
+		day := d.File.Date.Format("2006-01-02")
+		action := "删除"
+		if d.Action == retention.FrontPage {
+			action = "只保留头版"
+		}
+		name := d.File.Paper
+		if d.File.Collection {
+			name += " 合集"
+		}
+		fmt.Printf("- %s %s: %s（%s）, %s\n", day, name, action, d.Reason, formatBytes(d.File.Size))
+
+		if dryRun {
+			continue
+		}
+
+		size, err := applyDecision(cat, d)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "  清理失败: %v\n", err)
+			continue
+		}
+		freed += d.File.Size - size
+		cleaned++
+	}
+	if !dryRun {
+		freed += removeOrphanCollections(decisions)
+	}
+
+	fmt.Println("==================")
+	if dryRun {
+		fmt.Printf("将清理 %d 期报纸（未执行，去掉 --dry-run 后清理）\n", len(decisions))
+		return nil
+	}
+	fmt.Printf("已清理 %d 期报纸，释放 %s\n", cleaned, formatBytes(freed))
+	return nil
+}
+
+// applyDecision 执行清理并记录到下载目录，返回清理后文件的大小
+func applyDecision(cat *catalog.Catalog, d retention.Decision) (int64, error) {
+	day := d.File.Date.Format("2006-01-02")
+	e, ok := cat.Lookup(d.File.Paper, day)
+	if !ok {
+		e = catalog.Entry{Paper: d.File.Paper, Date: day, File: d.File.Path, FetchedAt: time.Now()}
+	}
+
+	switch d.Action {
+	case retention.Delete:
+		for _, path := range d.File.Paths() {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return 0, err
+			}
+		}
+		// 日期目录为空时一并删除
+		os.Remove(filepath.Dir(d.File.Paths()[0]))
+		e.Pruned = catalog.PrunedDeleted
+		e.SHA256 = ""
+		e.Size = 0
+
+	case retention.FrontPage:
+		// 派生文件由完整的报纸生成，只保留头版后一并删除
+		for _, path := range d.File.Extra {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return 0, err
+			}
+		}
+		if !d.File.FrontPage {
+			if err := crawler.KeepFrontPage(d.File.Path); err != nil {
+				return 0, err
+			}
+		}
+		sum, size, err := catalog.Checksum(d.File.Path)
+		if err != nil {
+			return 0, err
+		}
+		e.Pruned = catalog.PrunedFrontPage
+		e.SHA256 = sum
+		e.Size = size
+
+	default:
+		return d.File.Size, nil
+	}
+
+	// 合集和只剩派生文件的报纸不在下载目录中
+	if d.File.Collection || d.File.Path == "" {
+		return e.Size, nil
+	}
+	if err := catalog.Append(catalogPath, e); err != nil {
+		fmt.Printf("警告: 写入目录失败: %v\n", err)
+	}
+	return e.Size, nil
+}
+
+// removeOrphanCollections 删除清理后不再有报纸的日期目录中的合集，返回释放的大小
+func removeOrphanCollections(decisions []retention.Decision) int64 {
+	dirs := make(map[string]bool)
+	for _, d := range decisions {
+		if d.Action == retention.Delete && !d.File.Collection {
+			dirs[filepath.Dir(d.File.Paths()[0])] = true
+		}
+	}
+
+	editions, err := archivedEditions("dist")
+	if err != nil {
+		return 0
+	}
+	remaining := make(map[string][]archivedEdition)
+	for _, e := range editions {
+		dir := filepath.Dir(e.paths()[0])
+		if dirs[dir] {
+			remaining[dir] = append(remaining[dir], e)
+		}
+	}
+
+	var freed int64
+	for dir, list := range remaining {
+		orphan := true
+		for _, e := range list {
+			if !e.collection {
+				orphan = false
+				break
+			}
+		}
+		if !orphan {
+			continue
+		}
+		for _, e := range list {
+			for _, path := range e.paths() {
+				info, err := os.Stat(path)
+				if err != nil {
+					continue
+				}
+				if err := os.Remove(path); err != nil {
+					fmt.Fprintf(os.Stderr, "  清理失败: %v\n", err)
+					continue
+				}
+				freed += info.Size()
+			}
+			fmt.Printf("- %s %s 合集: 删除（当天的报纸已全部清理）\n", e.date, e.paper)
+		}
+		os.Remove(dir)
+	}
+	return freed
+}
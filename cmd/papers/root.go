@@ -3,6 +3,13 @@ package papers
 import (
 	"fmt"
 	"os"
+	"papers/internal/catalog"
+	"papers/internal/config"
+	"papers/internal/crawler"
+
+	// 各报纸系列在 init 中注册到爬虫的报纸注册表
+	_ "papers/internal/anhui"
+	_ "papers/internal/people"
 
 	"github.com/spf13/cobra"
 )
@@ -13,7 +20,16 @@ var rootCmd = &cobra.Command{
 	Long:  `一键下载并自动合并中国主流报纸的PDF版本`,
 }
 
+// configPath 配置文件路径
+var configPath string
+
+// catalogPath 下载目录文件路径，下载时写入，catalog、prune、backfill、verify 从中读取
+var catalogPath string
+
 func init() {
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", config.DefaultPath, "配置文件路径")
+	rootCmd.PersistentFlags().StringVar(&catalogPath, "catalog", catalog.DefaultPath, "下载目录文件路径")
+
 	// 禁用自动生成的 completion 命令
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
@@ -25,6 +41,29 @@ func init() {
 	})
 }
 
+// loadConfig 读取配置文件，配置文件不存在时使用默认配置，读取失败时退出
+func loadConfig() *config.Config {
+	cfg, err := readConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	return cfg
+}
+
+// readConfig 读取配置文件并应用到已注册的报纸，配置文件不存在时使用默认配置
+// 守护进程每次执行任务时重新读取，配置有误时只让本次任务失败
+func readConfig() (*config.Config, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("加载配置失败: %v", err)
+	}
+	if err := crawler.ApplyConfig(cfg); err != nil {
+		return nil, fmt.Errorf("加载配置失败: %v", err)
+	}
+	return cfg, nil
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println("Error:", err)
@@ -0,0 +1,238 @@
+package papers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"papers/internal/config"
+	"papers/internal/crawler"
+	"papers/internal/history"
+	"papers/internal/scheduler"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "以守护进程方式运行定时下载任务",
+	Long: `以守护进程方式运行，按配置文件中的定时任务自动下载报纸
+
+定时任务在配置文件（默认 papers.json）的 schedule 中配置:
+
+  {
+    "schedule": {
+      "catch_up_days": 7,
+      "jobs": [
+        {"name": "daily", "papers": ["rmrb", "jksb"], "at": "07:00", "timezone": "Asia/Shanghai"},
+        {"name": "weekly", "papers": ["fcyym"], "at": "09:00", "weekdays": ["fri"]}
+      ]
+    }
+  }
+
+最近一次执行记录保存在状态目录（默认 .papers）中，重启后会补跑停机期间错过的任务。
+任务执行失败时按 15、30、60 分钟的间隔重试，同一次任务最多执行 4 次。
+收到 SIGINT 或 SIGTERM 时，在当前报纸下载完成后退出。
+
+示例:
+  papers serve --schedule
+  papers serve --schedule --config /etc/papers.json
+
+  # 根据历史发布时间建议定时任务的执行时间
+  papers serve --suggest`,
+	Run: runServe,
+}
+
+var (
+	serveSchedule bool
+	serveSuggest  bool
+)
+
+func init() {
+	serveCmd.Flags().BoolVar(&serveSchedule, "schedule", false, "按配置文件中的定时任务运行")
+	serveCmd.Flags().BoolVar(&serveSuggest, "suggest", false, "根据历史发布时间建议各定时任务的执行时间，不启动守护进程")
+
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) {
+	if serveSuggest {
+		suggestSchedule()
+		return
+	}
+	if !serveSchedule {
+		fmt.Fprintln(os.Stderr, "目前仅支持 --schedule 模式")
+		os.Exit(1)
+	}
+
+	cfg := loadConfig()
+
+	// 启动前校验任务中的报纸、质量预设和封面字体
+	for _, job := range cfg.Schedule.Jobs {
+		if _, err := resolvePapers(strings.Join(job.Papers, ",")); err != nil {
+			fmt.Fprintf(os.Stderr, "定时任务 %s 配置错误: %v\n", job.Name, err)
+			os.Exit(1)
+		}
+		if _, err := crawler.ParseQualityPreset(job.Quality); err != nil {
+			fmt.Fprintf(os.Stderr, "定时任务 %s 配置错误: %v\n", job.Name, err)
+			os.Exit(1)
+		}
+		if job.Cover && cfg.Font == "" {
+			fmt.Fprintf(os.Stderr, "定时任务 %s 配置错误: cover 需要指定支持中文的字体 (font)\n", job.Name)
+			os.Exit(1)
+		}
+	}
+
+	s, err := scheduler.New(cfg, runScheduledJob)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "创建调度器失败: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Println("=== papers 定时任务守护进程 ===")
+	if err := s.Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "调度器异常退出: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runScheduledJob 执行一次定时任务，下载任务日期的所有报纸
+func runScheduledJob(ctx context.Context, job config.Job, at time.Time) error {
+	date, err := crawler.ParseDate(at.Format("2006-01-02"))
+	if err != nil {
+		return err
+	}
+
+	opts := downloadOptions{quality: job.Quality, cover: job.Cover}
+	if err := opts.parse(); err != nil {
+		return err
+	}
+
+	papers, err := resolvePapers(strings.Join(job.Papers, ","))
+	if err != nil {
+		return err
+	}
+
+	summary := &downloadSummary{}
+	for _, p := range papers {
+		// 收到退出信号时，不再开始新的下载
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c := p.NewCrawler(date)
+		if opts.scheduled(c, summary) {
+			opts.runOne(c, summary)
+		}
+	}
+	summary.print()
+
+	if summary.success > 0 {
+		autoPrune(opts.config)
+	}
+
+	if summary.fail > 0 {
+		return fmt.Errorf("%d 份报纸下载失败", summary.fail)
+	}
+	return nil
+}
+
+// suggestMargin 建议执行时间在预计发布时间之后预留的余量
+const suggestMargin = 15 * time.Minute
+
+// suggestSchedule 根据历史发布时间为每个定时任务建议执行时间
+func suggestSchedule() {
+	cfg := loadConfig()
+	ph := loadPublishHistory(cfg)
+
+	jobs := cfg.Schedule.Jobs
+	if len(jobs) == 0 {
+		// 没有配置定时任务时，为每份报纸单独给出建议
+		for _, p := range crawler.Papers() {
+			jobs = append(jobs, config.Job{Name: p.Code, Papers: []string{p.Code}})
+		}
+	}
+
+	fmt.Println("=== 定时任务执行时间建议 ===")
+	for _, job := range jobs {
+		current := job.At
+		if current == "" {
+			current = "未配置"
+		}
+		suggested, details, ok := suggestJob(ph.history, job)
+		if !ok {
+			fmt.Printf("• %s: 当前 %s, 暂无发布记录 (%s)\n", job.Name, current, strings.Join(details, ", "))
+			continue
+		}
+		fmt.Printf("• %s: 当前 %s, 建议 %s (%s)\n", job.Name, current, formatClock(suggested), strings.Join(details, ", "))
+	}
+}
+
+// suggestJob 返回定时任务的建议执行时间和各报纸的预计发布时间
+// 建议时间为任务中各报纸在其执行星期内最晚的预计发布时间加上余量，向上取整到5分钟
+func suggestJob(h *history.History, job config.Job) (time.Duration, []string, bool) {
+	weekdays := make([]time.Weekday, 0, 7)
+	if len(job.Weekdays) == 0 {
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			weekdays = append(weekdays, d)
+		}
+	}
+	for _, w := range job.Weekdays {
+		if d, err := config.ParseWeekday(w); err == nil {
+			weekdays = append(weekdays, d)
+		}
+	}
+
+	var latest time.Duration
+	found := false
+	var details []string
+	for _, code := range job.Papers {
+		paperLatest := time.Duration(-1)
+		for _, d := range weekdays {
+			if p, ok := crawler.LookupPaper(code); ok && p.Schedule != nil && !publishesOnWeekday(p, d) {
+				continue
+			}
+			profile, ok := h.Expected(code, d)
+			if ok && profile.Expected > paperLatest {
+				paperLatest = profile.Expected
+			}
+		}
+		if paperLatest < 0 {
+			details = append(details, code+" 无记录")
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s %s", code, formatClock(paperLatest)))
+		if !found || paperLatest > latest {
+			latest = paperLatest
+		}
+		found = true
+	}
+	if !found {
+		return 0, details, false
+	}
+	return (latest + suggestMargin + 4*time.Minute).Truncate(5 * time.Minute), details, true
+}
+
+// publishesOnWeekday 判断报纸的出版规则是否包含指定星期
+func publishesOnWeekday(p *crawler.Paper, d time.Weekday) bool {
+	if len(p.Schedule.Weekdays) == 0 {
+		return true
+	}
+	for _, w := range p.Schedule.Weekdays {
+		if w == d {
+			return true
+		}
+	}
+	return false
+}
+
+// formatClock 将距零点的时长格式化为 HH:MM
+func formatClock(d time.Duration) string {
+	m := int(d.Minutes())
+	return fmt.Sprintf("%02d:%02d", m/60%24, m%60)
+}
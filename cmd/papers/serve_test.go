@@ -0,0 +1,66 @@
+package papers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"papers/internal/config"
+	"papers/internal/history"
+)
+
+func TestSuggestJob(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	h := history.New()
+	// observe 记录一期报纸在 missing 时尚未发布、在 seen 时已经可用，missing 为空表示只观察到可用
+	observe := func(code, day, missing, seen string) {
+		edition, _ := time.ParseInLocation("2006-01-02", day, shanghai)
+		clock := func(s string) time.Time {
+			ts, _ := time.ParseInLocation("2006-01-02 15:04", day+" "+s, shanghai)
+			return ts
+		}
+		if missing != "" {
+			h.ObserveMissing(code, edition, clock(missing))
+		}
+		h.Observe(code, edition, clock(seen))
+	}
+
+	// 健康时报星期二、五出版，2025-11-11 为星期二
+	for _, day := range []string{"2025-11-11", "2025-11-18", "2025-11-25"} {
+		observe("jksb", day, "05:00", "05:20")
+		observe("rmrb", day, "05:20", "05:44")
+	}
+	// 定时任务按时下载成功，只得到上限
+	for _, day := range []string{"2025-11-12", "2025-11-13", "2025-11-14", "2025-11-15"} {
+		observe("rmrb", day, "", "06:00")
+	}
+	observe("zgcsb", "2025-11-17", "", "05:00")
+
+	tests := []struct {
+		name    string
+		job     config.Job
+		want    string
+		details string
+		ok      bool
+	}{
+		{name: "余量后取整到5分钟", job: config.Job{Papers: []string{"jksb"}}, want: "05:25", details: "jksb 05:10", ok: true},
+		{name: "取最晚的报纸", job: config.Job{Papers: []string{"jksb", "rmrb"}}, want: "05:50", details: "jksb 05:10, rmrb 05:32", ok: true},
+		{name: "非出版日不参与", job: config.Job{Papers: []string{"jksb"}, Weekdays: []string{"mon"}}, details: "jksb 无记录"},
+		{name: "只有首次可用时间", job: config.Job{Papers: []string{"zgcsb"}}, details: "zgcsb 无记录"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggested, details, ok := suggestJob(h, tt.job)
+			if ok != tt.ok {
+				t.Fatalf("suggestJob() ok = %v, want %v", ok, tt.ok)
+			}
+			if got := strings.Join(details, ", "); got != tt.details {
+				t.Errorf("details = %q, want %q", got, tt.details)
+			}
+			if ok && formatClock(suggested) != tt.want {
+				t.Errorf("suggested = %s, want %s", formatClock(suggested), tt.want)
+			}
+		})
+	}
+}
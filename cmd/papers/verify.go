@@ -0,0 +1,178 @@
+package papers
+
+import (
+	"fmt"
+	"os"
+	"papers/internal/catalog"
+	"papers/internal/crawler"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "校验已下载报纸的完整性",
+	Long: `校验 dist 下所有合并后的PDF文件
+
+对每个文件:
+  • 使用pdfcpu校验PDF结构，发现截断或损坏的文件
+  • 检查空文件和没有页面的文件
+  • 与下载目录 (dist/catalog.jsonl，可用 --catalog 指定) 中记录的sha256比较
+  • 检查目录中有记录但文件已丢失的报纸
+
+发现问题时退出码为1；使用 --redownload 重新下载有问题的报纸，
+全部重新下载成功时退出码为0。
+
+示例:
+  # 校验所有报纸
+  papers verify
+
+  # 校验指定日期范围内的报纸
+  papers verify --from 2025-01-01 --to 2025-06-30
+
+  # 校验并重新下载有问题的报纸
+  papers verify --from 2025-01-01 --redownload`,
+	Run: runVerify,
+}
+
+var (
+	verifyFrom       string
+	verifyTo         string
+	verifyRedownload bool
+	verifyDelay      time.Duration
+	verifyOptions    downloadOptions
+)
+
+func init() {
+	verifyCmd.Flags().StringVar(&verifyFrom, "from", "", "起始日期（含），格式: YYYY-MM-DD")
+	verifyCmd.Flags().StringVar(&verifyTo, "to", "", "结束日期（含），格式: YYYY-MM-DD")
+	verifyCmd.Flags().BoolVar(&verifyRedownload, "redownload", false, "重新下载有问题的报纸")
+	verifyCmd.Flags().DurationVar(&verifyDelay, "delay", 30*time.Second, "重新下载时两期报纸之间的等待时间")
+	verifyOptions.addQualityFlags(verifyCmd)
+
+	rootCmd.AddCommand(verifyCmd)
+}
+
+// problem 校验发现的问题
+type problem struct {
+	file   archivedFile
+	reason string
+}
+
+func runVerify(cmd *cobra.Command, args []string) {
+	for _, d := range []string{verifyFrom, verifyTo} {
+		if d == "" {
+			continue
+		}
+		if _, err := crawler.ParseDate(d); err != nil {
+			fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if err := verifyOptions.parse(); err != nil {
+		fmt.Fprintf(os.Stderr, "参数错误: %v\n", err)
+		os.Exit(1)
+	}
+
+	cat, err := catalog.Load(catalogPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	files, err := archivedFiles("dist", verifyFrom, verifyTo)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "读取 dist 目录失败: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("=== 校验 %d 个文件 ===\n", len(files))
+
+	var problems []problem
+	seen := make(map[string]bool)
+	ok := 0
+	for _, f := range files {
+		seen[f.paper+"/"+f.date] = true
+		if reason := verifyFile(cat, f); reason != "" {
+			fmt.Printf("✗ %s %s: %s\n", f.date, f.paper, reason)
+			problems = append(problems, problem{file: f, reason: reason})
+			continue
+		}
+		ok++
+	}
+
+	// 目录中有记录但文件已丢失
+	for _, e := range cat.Query(catalog.Query{From: verifyFrom, To: verifyTo}) {
+		if seen[e.Paper+"/"+e.Date] || e.Pruned == catalog.PrunedDeleted {
+			continue
+		}
+		fmt.Printf("✗ %s %s: 文件丢失 (%s)\n", e.Date, e.Paper, e.File)
+		problems = append(problems, problem{file: archivedFile{paper: e.Paper, date: e.Date, path: e.File}, reason: "文件丢失"})
+	}
+
+	fmt.Println("==================")
+	fmt.Printf("校验完成! 正常: %d, 有问题: %d\n", ok, len(problems))
+
+	if len(problems) == 0 {
+		return
+	}
+	if !verifyRedownload {
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	if !redownload(problems) {
+		os.Exit(1)
+	}
+}
+
+// verifyFile 校验单个文件，返回发现的问题，没有问题时返回空字符串
+func verifyFile(cat *catalog.Catalog, f archivedFile) string {
+	sum, size, err := catalog.Checksum(f.path)
+	if err != nil {
+		return fmt.Sprintf("读取失败: %v", err)
+	}
+	if size == 0 {
+		return "空文件"
+	}
+
+	if e, ok := cat.Lookup(f.paper, f.date); ok && e.SHA256 != "" && e.SHA256 != sum {
+		return fmt.Sprintf("sha256 与目录记录不一致 (大小 %s，记录为 %s)", formatBytes(size), formatBytes(e.Size))
+	}
+
+	pages, err := crawler.ValidatePDF(f.path)
+	if err != nil {
+		return fmt.Sprintf("PDF校验失败: %v", err)
+	}
+	if pages == 0 {
+		return "没有页面"
+	}
+	return ""
+}
+
+// redownload 重新下载有问题的报纸，全部成功时返回 true
+func redownload(problems []problem) bool {
+	summary := &downloadSummary{}
+	for i, p := range problems {
+		paper, ok := crawler.LookupPaper(p.file.paper)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "报纸 %s 未注册，无法重新下载\n\n", p.file.paper)
+			summary.fail++
+			continue
+		}
+		date, err := crawler.ParseDate(p.file.date)
+		if err != nil {
+			summary.fail++
+			continue
+		}
+
+		if i > 0 && verifyDelay > 0 {
+			fmt.Printf("等待 %s 后继续...\n\n", verifyDelay)
+			time.Sleep(verifyDelay)
+		}
+		verifyOptions.runOne(paper.NewCrawler(date), summary)
+	}
+	summary.print()
+	return summary.fail == 0
+}
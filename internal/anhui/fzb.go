@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"papers/internal/crawler"
 	"strings"
 	"time"
 
@@ -68,8 +69,8 @@ func (f *FZBFetcher) GetPageCount(url string) (int, error) {
 	return count, nil
 }
 
-// FindPDFURL 从页面中查找PDF下载链接
-func (f *FZBFetcher) FindPDFURL(doc *goquery.Document, baseURL string) (string, error) {
+// FindAsset 从页面中查找PDF下载链接
+func (f *FZBFetcher) FindAsset(doc *goquery.Document, baseURL string) (*crawler.PageAsset, error) {
 	var pdfURL string
 
 	// 查找PDF链接 - 使用FZB特定的选择器
@@ -92,22 +93,24 @@ func (f *FZBFetcher) FindPDFURL(doc *goquery.Document, baseURL string) (string,
 	}
 
 	if pdfURL == "" {
-		return "", fmt.Errorf("未找到PDF链接")
+		return nil, fmt.Errorf("未找到PDF链接")
 	}
 
 	// 使用url.Parse解析相对路径
 	base, err := url.Parse(baseURL)
 	if err != nil {
-		return "", fmt.Errorf("解析基础URL失败: %v", err)
+		return nil, fmt.Errorf("解析基础URL失败: %v", err)
 	}
 
 	pdfURLParsed, err := url.Parse(pdfURL)
 	if err != nil {
-		return "", fmt.Errorf("解析PDF URL失败: %v", err)
+		return nil, fmt.Errorf("解析PDF URL失败: %v", err)
 	}
 
 	// 使用ResolveReference将相对路径转换为绝对路径
 	absoluteURL := base.ResolveReference(pdfURLParsed)
 
-	return absoluteURL.String(), nil
+	return crawler.NewPDFAsset(absoluteURL.String()).
+		WithHeading(crawler.FindPageHeading(doc)).
+		WithHeadlines(crawler.FindHeadlines(doc)), nil
 }
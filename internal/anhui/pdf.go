@@ -2,6 +2,7 @@ package anhui
 
 import (
 	"papers/internal/crawler"
+	"time"
 )
 
 // NewCrawler 创建新的安徽日报系列爬虫实例
@@ -12,3 +13,63 @@ import (
 func NewCrawler(paperType string, fetcher crawler.PaperFetcher, dateStr string) (*crawler.Crawler, error) {
 	return crawler.NewCrawler(paperType, fetcher, dateStr)
 }
+
+// publisher 安徽日报系列报纸的出版单位
+const publisher = "安徽日报报业集团"
+
+func init() {
+	crawler.Register(&crawler.Paper{
+		Code:      "ahrb",
+		Name:      "安徽日报",
+		Family:    "anhui",
+		Publisher: publisher,
+		NewFetcher: func(date time.Time) crawler.PaperFetcher {
+			return NewAHRBFetcher(date)
+		},
+	})
+	crawler.Register(&crawler.Paper{
+		Code:      "ncb",
+		Name:      "安徽日报农村版",
+		Family:    "anhui",
+		Publisher: publisher,
+		NewFetcher: func(date time.Time) crawler.PaperFetcher {
+			return NewNCBFetcher(date)
+		},
+	})
+	crawler.Register(&crawler.Paper{
+		Code:      "jhsb",
+		Name:      "江淮时报",
+		Family:    "anhui",
+		Publisher: publisher,
+		NewFetcher: func(date time.Time) crawler.PaperFetcher {
+			return NewJHSBFetcher(date)
+		},
+	})
+	crawler.Register(&crawler.Paper{
+		Code:      "fzb",
+		Name:      "安徽法治报",
+		Family:    "anhui",
+		Publisher: publisher,
+		NewFetcher: func(date time.Time) crawler.PaperFetcher {
+			return NewFZBFetcher(date)
+		},
+	})
+	crawler.Register(&crawler.Paper{
+		Code:      "pc",
+		Name:      "安徽商报",
+		Family:    "anhui",
+		Publisher: publisher,
+		NewFetcher: func(date time.Time) crawler.PaperFetcher {
+			return NewPCFetcher(date)
+		},
+	})
+	crawler.Register(&crawler.Paper{
+		Code:      "xawb",
+		Name:      "新安晚报",
+		Family:    "anhui",
+		Publisher: publisher,
+		NewFetcher: func(date time.Time) crawler.PaperFetcher {
+			return NewXAWBFetcher(date)
+		},
+	})
+}
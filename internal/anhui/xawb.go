@@ -2,33 +2,26 @@ package anhui
 
 import (
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
-	"os"
-	"path/filepath"
+	"papers/internal/crawler"
 	"regexp"
-	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
-	"github.com/pdfcpu/pdfcpu/pkg/api"
-	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 )
 
 // XAWBFetcher 新安晚报的特定获取逻辑
 type XAWBFetcher struct {
-	date      time.Time
-	outputDir string   // 用于存储临时JPG文件
-	pageURLs  []string // 缓存所有版面的URL
+	date     time.Time
+	pageURLs []string // 缓存所有版面的URL
 }
 
 // NewXAWBFetcher 创建新安晚报获取器
-func NewXAWBFetcher(date time.Time, outputDir string) *XAWBFetcher {
+func NewXAWBFetcher(date time.Time) *XAWBFetcher {
 	return &XAWBFetcher{
-		date:      date,
-		outputDir: outputDir,
-		pageURLs:  make([]string, 0),
+		date:     date,
+		pageURLs: make([]string, 0),
 	}
 }
 
@@ -126,9 +119,16 @@ func (f *XAWBFetcher) GetPageCount(url string) (int, error) {
 	return count, nil
 }
 
-// FindPDFURL 从页面中查找PDF下载链接
-// 对于XAWB，这个方法实际上是查找JPG图片URL，然后转换为PDF
-func (f *XAWBFetcher) FindPDFURL(doc *goquery.Document, baseURL string) (string, error) {
+// ImageOptions 新安晚报为对开报纸，版面图片没有记录分辨率，按对开幅面设置页面尺寸
+func (f *XAWBFetcher) ImageOptions() crawler.ImageOptions {
+	opts := crawler.DefaultImageOptions()
+	opts.PaperSize = "broadsheet"
+	return opts
+}
+
+// FindAsset 从页面中查找版面资源
+// 对于XAWB，版面只提供图片，由爬虫下载后转换为PDF
+func (f *XAWBFetcher) FindAsset(doc *goquery.Document, baseURL string) (*crawler.PageAsset, error) {
 	var imageURL string
 
 	// 查找图片URL - 从 #sss > div 中提取 background-image
@@ -148,94 +148,32 @@ func (f *XAWBFetcher) FindPDFURL(doc *goquery.Document, baseURL string) (string,
 	if imageURL == "" {
 		doc.Find("#sss img").Each(func(i int, s *goquery.Selection) {
 			src, exists := s.Attr("src")
-			if exists && (strings.HasSuffix(src, ".jpg") || strings.HasSuffix(src, ".jpeg")) {
+			if exists && crawler.IsImageURL(src) {
 				imageURL = src
 			}
 		})
 	}
 
 	if imageURL == "" {
-		return "", fmt.Errorf("未找到图片URL")
+		return nil, fmt.Errorf("未找到图片URL")
 	}
 
 	// 解析相对路径为绝对路径
 	base, err := url.Parse(baseURL)
 	if err != nil {
-		return "", fmt.Errorf("解析基础URL失败: %v", err)
+		return nil, fmt.Errorf("解析基础URL失败: %v", err)
 	}
 
 	imgURLParsed, err := url.Parse(imageURL)
 	if err != nil {
-		return "", fmt.Errorf("解析图片URL失败: %v", err)
+		return nil, fmt.Errorf("解析图片URL失败: %v", err)
 	}
 
 	absoluteURL := base.ResolveReference(imgURLParsed)
-	absoluteImageURL := absoluteURL.String()
-
-	// 下载图片并转换为PDF
-	pdfPath, err := f.downloadImageAndConvertToPDF(absoluteImageURL, baseURL)
-	if err != nil {
-		return "", fmt.Errorf("下载图片或转换PDF失败: %v", err)
-	}
-
-	// 返回本地PDF文件路径（使用特殊前缀标记为本地文件）
-	return "file://" + pdfPath, nil
-}
-
-// downloadImageAndConvertToPDF 下载JPG图片并转换为PDF
-func (f *XAWBFetcher) downloadImageAndConvertToPDF(imageURL, pageURL string) (string, error) {
-	// 下载图片
-	resp, err := http.Get(imageURL)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("下载图片失败，HTTP状态码: %d", resp.StatusCode)
-	}
-
-	// 生成临时JPG文件名
-	timestamp := time.Now().UnixNano()
-	jpgPath := filepath.Join(f.outputDir, fmt.Sprintf("temp_%d.jpg", timestamp))
-
-	// 保存JPG文件
-	jpgFile, err := os.Create(jpgPath)
-	if err != nil {
-		return "", err
-	}
-
-	_, err = io.Copy(jpgFile, resp.Body)
-	jpgFile.Close()
-	if err != nil {
-		os.Remove(jpgPath)
-		return "", err
-	}
-
-	// 转换为PDF
-	pdfPath := filepath.Join(f.outputDir, fmt.Sprintf("temp_%d.pdf", timestamp))
-	err = f.convertJPGToPDF(jpgPath, pdfPath)
-	if err != nil {
-		os.Remove(jpgPath)
-		return "", err
-	}
-
-	// 删除临时JPG文件
-	os.Remove(jpgPath)
-
-	return pdfPath, nil
-}
-
-// convertJPGToPDF 将JPG图片转换为PDF文件
-func (f *XAWBFetcher) convertJPGToPDF(jpgPath, pdfPath string) error {
-	// 使用pdfcpu的ImportImages功能将图片转换为PDF
-	conf := model.NewDefaultConfiguration()
-
-	// ImportImages参数: sourceFiles(图片文件列表), outputFile, importConf, config
-	err := api.ImportImagesFile([]string{jpgPath}, pdfPath, nil, conf)
-	if err != nil {
-		return fmt.Errorf("转换图片为PDF失败: %v", err)
-	}
 
-	return nil
+	// 部分图片服务器会校验来源页面
+	return crawler.NewImageAsset(absoluteURL.String()).
+		WithHeader("Referer", baseURL).
+		WithHeading(crawler.FindPageHeading(doc)).
+		WithHeadlines(crawler.FindHeadlines(doc)), nil
 }
@@ -0,0 +1,200 @@
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DefaultPath 默认的目录文件路径，与合并后的PDF放在同一目录下
+var DefaultPath = filepath.Join("dist", "catalog.jsonl")
+
+// Entry 一期已下载报纸的记录
+type Entry struct {
+	Paper        string    `json:"paper"`                   // 报纸代码
+	Date         string    `json:"date"`                    // 出版日期，格式: 2006-01-02
+	PageCount    int       `json:"page_count"`              // 总版数
+	MissingPages []int     `json:"missing_pages,omitempty"` // 下载失败的版面
+	File         string    `json:"file"`                    // 合并后的PDF路径
+	SHA256       string    `json:"sha256"`
+	Size         int64     `json:"size"`
+	Sources      []string  `json:"sources"` // 各版面资源的地址或本地路径，内存中的数据不记录
+	FetchedAt    time.Time `json:"fetched_at"`
+	Pruned       string    `json:"pruned,omitempty"` // 按保留策略清理的方式，见 PrunedDeleted、PrunedFrontPage
+}
+
+// 按保留策略清理的方式
+const (
+	PrunedDeleted   = "deleted"    // 文件已删除
+	PrunedFrontPage = "front_page" // 只保留头版
+)
+
+// Complete 判断是否所有版面都已下载
+func (e Entry) Complete() bool {
+	return len(e.MissingPages) == 0
+}
+
+// NewEntry 根据合并后的PDF文件创建记录，计算文件大小和sha256
+func NewEntry(paper string, date time.Time, file string) (Entry, error) {
+	sum, size, err := Checksum(file)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	return Entry{
+		Paper:     paper,
+		Date:      date.Format("2006-01-02"),
+		File:      file,
+		SHA256:    sum,
+		Size:      size,
+		FetchedAt: time.Now(),
+	}, nil
+}
+
+// Checksum 计算文件的sha256和大小
+func Checksum(file string) (string, int64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// Append 将记录追加到目录文件末尾
+// 重新下载同一期时追加新记录，读取时以最后一条为准
+func Append(path string, e Entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(e); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Catalog 已下载报纸的目录，每期只保留最新的记录
+type Catalog struct {
+	Entries []Entry // 按日期、报纸代码排序
+	index   map[string]int
+}
+
+// Load 读取目录文件，文件不存在时返回空目录
+func Load(path string) (*Catalog, error) {
+	c := &Catalog{index: make(map[string]int)}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取目录失败: %v", err)
+	}
+	defer f.Close()
+
+	latest := make(map[string]Entry)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("解析目录 %s 第 %d 行失败: %v", path, line, err)
+		}
+		latest[key(e.Paper, e.Date)] = e
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取目录失败: %v", err)
+	}
+
+	for _, e := range latest {
+		c.Entries = append(c.Entries, e)
+	}
+	sort.Slice(c.Entries, func(i, j int) bool {
+		a, b := c.Entries[i], c.Entries[j]
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		return a.Paper < b.Paper
+	})
+	for i, e := range c.Entries {
+		c.index[key(e.Paper, e.Date)] = i
+	}
+
+	return c, nil
+}
+
+// key 目录中一期报纸的索引键
+func key(paper, date string) string {
+	return paper + "/" + date
+}
+
+// Lookup 查找某份报纸某天的记录，date 格式: 2006-01-02
+func (c *Catalog) Lookup(paper, date string) (Entry, bool) {
+	i, ok := c.index[key(paper, date)]
+	if !ok {
+		return Entry{}, false
+	}
+	return c.Entries[i], true
+}
+
+// Query 目录查询条件，字段为空表示不限制
+type Query struct {
+	Papers []string
+	From   string // 起始日期（含），格式: 2006-01-02
+	To     string // 结束日期（含），格式: 2006-01-02
+}
+
+// Query 返回满足条件的记录
+func (c *Catalog) Query(q Query) []Entry {
+	papers := make(map[string]bool, len(q.Papers))
+	for _, p := range q.Papers {
+		papers[p] = true
+	}
+
+	var entries []Entry
+	for _, e := range c.Entries {
+		if len(papers) > 0 && !papers[e.Paper] {
+			continue
+		}
+		if q.From != "" && e.Date < q.From {
+			continue
+		}
+		if q.To != "" && e.Date > q.To {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
@@ -0,0 +1,97 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAppendLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dist", "catalog.jsonl")
+	date := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+
+	file := filepath.Join(dir, "rmrb_20251110.pdf")
+	if err := os.WriteFile(file, []byte("%PDF-1.7"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	first, err := NewEntry("rmrb", date, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.PageCount = 2
+	first.MissingPages = []int{2}
+	first.Sources = []string{"http://paper.people.com.cn/rmrb/pc/1.pdf"}
+
+	// 重新下载同一期，以最后一条为准
+	redownload := first
+	redownload.MissingPages = nil
+	redownload.Sources = []string{"http://paper.people.com.cn/rmrb/pc/1.pdf", "/tmp/rmrb/2.pdf"}
+	redownload.FetchedAt = first.FetchedAt.Add(time.Hour)
+
+	other := Entry{Paper: "jksb", Date: "2025-11-11", PageCount: 8, File: "dist/jksb_20251111.pdf", Pruned: PrunedFrontPage}
+
+	for _, e := range []Entry{first, other, redownload} {
+		if err := Append(path, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Entries) != 2 || c.Entries[0].Paper != "rmrb" || c.Entries[1].Paper != "jksb" {
+		t.Fatalf("Entries = %v, want rmrb then jksb", c.Entries)
+	}
+
+	tests := []struct {
+		name  string
+		paper string
+		date  string
+		want  Entry
+		ok    bool
+	}{
+		{name: "最后一条记录", paper: "rmrb", date: "2025-11-10", want: redownload, ok: true},
+		{name: "已清理的记录", paper: "jksb", date: "2025-11-11", want: other, ok: true},
+		{name: "不存在的记录", paper: "rmrb", date: "2025-11-11"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := c.Lookup(tt.paper, tt.date)
+			if ok != tt.ok {
+				t.Fatalf("Lookup(%s, %s) ok = %v, want %v", tt.paper, tt.date, ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			// 时间经过 JSON 往返后只比较时刻
+			if !got.FetchedAt.Equal(tt.want.FetchedAt) {
+				t.Errorf("FetchedAt = %v, want %v", got.FetchedAt, tt.want.FetchedAt)
+			}
+			got.FetchedAt, tt.want.FetchedAt = time.Time{}, time.Time{}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup(%s, %s) = %+v, want %+v", tt.paper, tt.date, got, tt.want)
+			}
+			if got.Complete() != (len(tt.want.MissingPages) == 0) {
+				t.Errorf("Complete() = %v", got.Complete())
+			}
+		})
+	}
+
+	if got := c.Query(Query{From: "2025-11-11"}); len(got) != 1 || got[0].Paper != "jksb" {
+		t.Errorf("Query(From) = %v, want jksb only", got)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	c, err := Load(filepath.Join(t.TempDir(), "catalog.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Entries) != 0 {
+		t.Errorf("Entries = %v, want none", c.Entries)
+	}
+}
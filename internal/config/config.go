@@ -0,0 +1,229 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultPath 默认的配置文件路径
+const DefaultPath = "papers.json"
+
+// Config papers 的配置文件
+type Config struct {
+	// StateDir 保存运行状态的目录，默认为 .papers
+	StateDir string `json:"state_dir"`
+	// Schedule 定时任务配置
+	Schedule Schedule `json:"schedule"`
+	// Retention 本地归档的保留策略
+	Retention Retention `json:"retention"`
+	// Stamps 合并后PDF的水印和页眉，每份报纸使用第一条匹配的配置
+	Stamps []Stamp `json:"stamps"`
+	// Font 生成封面、目录等页面使用的字体，需支持中文；也是水印和页眉的默认字体
+	// 字体文件路径（ttf、ttc、otf），或已安装到状态目录 fonts 中的字体名
+	Font string `json:"font"`
+	// Papers 按报纸代码覆盖单份报纸的默认设置，如 {"xawb": {"paper_size": "tabloid"}}
+	Papers map[string]PaperConfig `json:"papers"`
+}
+
+// PaperConfig 单份报纸的设置，为空的项使用获取器的默认值
+type PaperConfig struct {
+	// PaperSize 图片版面的物理尺寸，如 "A3"、"broadsheet"（对开）、"tabloid"（四开）、"350x500mm"
+	PaperSize string `json:"paper_size"`
+	// Schedule 覆盖注册的出版规则，休刊日等每年变化的规则在这里配置
+	Schedule *PaperSchedule `json:"schedule"`
+}
+
+// PaperSchedule 报纸的出版规则，为空的项沿用注册的规则
+type PaperSchedule struct {
+	Weekdays   []string `json:"weekdays"`     // 出版的星期，如 ["tue", "fri"]
+	EveryNDays int      `json:"every_n_days"` // 每隔N天出版一次
+	Anchor     string   `json:"anchor"`       // every_n_days 的起算日期，应为某个已知的出版日，格式: 2006-01-02
+	Holidays   []string `json:"holidays"`     // 休刊日期，追加到注册的休刊日，格式: 2006-01-02
+}
+
+// Schedule 定时任务配置
+type Schedule struct {
+	// CatchUpDays 启动时最多补跑多少天内错过的任务，默认为 7
+	CatchUpDays int   `json:"catch_up_days"`
+	Jobs        []Job `json:"jobs"`
+}
+
+// Job 一个定时下载任务
+type Job struct {
+	Name     string   `json:"name"`
+	Papers   []string `json:"papers"`   // 报纸代码，如 ["rmrb", "jksb"]
+	At       string   `json:"at"`       // 执行时间，格式: HH:MM
+	Timezone string   `json:"timezone"` // 时区，默认为 Asia/Shanghai
+	Weekdays []string `json:"weekdays"` // 执行的星期，如 ["fri"]，为空表示每天
+	Quality  string   `json:"quality"`  // 图片版面的质量预设
+	Cover    bool     `json:"cover"`    // 在合并后的PDF前插入封面和目录
+}
+
+// Retention 本地归档的保留策略
+type Retention struct {
+	// Auto 每次下载完成后自动按保留策略清理
+	Auto bool `json:"auto"`
+	// Pins 永不清理的报纸，格式: rmrb/2025-01-01 表示某一期，rmrb 表示该报纸的所有期
+	Pins []string `json:"pins"`
+	// Rules 保留规则，每份报纸使用第一条匹配的规则，没有匹配的规则时不清理
+	Rules []RetentionRule `json:"rules"`
+}
+
+// RetentionRule 一条保留规则，各项为0或空表示不限制
+type RetentionRule struct {
+	Papers []string `json:"papers"` // 适用的报纸代码
+	Family string   `json:"family"` // 适用的报纸系列，与 papers 都为空时适用于所有报纸
+
+	KeepDays           int      `json:"keep_days"`             // 只保留最近N天
+	WeekdaysAfterDays  int      `json:"weekdays_after_days"`   // N天后只保留 weekdays 中的星期
+	Weekdays           []string `json:"weekdays"`              // 默认为 ["mon"]
+	FrontPageAfterDays int      `json:"front_page_after_days"` // N天后只保留头版
+	MaxSize            string   `json:"max_size"`              // 总大小上限，如 10GB，超出时从最旧的开始删除
+}
+
+// Stamp 合并后PDF的水印和页眉配置
+type Stamp struct {
+	Papers []string `json:"papers"` // 适用的报纸代码
+	Family string   `json:"family"` // 适用的报纸系列，与 papers 都为空时适用于所有报纸
+
+	// Font 字体文件路径（ttf、ttc、otf），或已安装到状态目录 fonts 中的字体名，默认使用全局的 font
+	// 中文文字需要使用中文字体，如 NotoSansCJK、思源黑体
+	Font string `json:"font"`
+
+	Watermark *Watermark `json:"watermark"`
+	Header    *Header    `json:"header"`
+}
+
+// Watermark 水印，文字和图片二选一
+type Watermark struct {
+	Text     string  `json:"text"`
+	Image    string  `json:"image"`    // 图片文件路径
+	Opacity  float64 `json:"opacity"`  // 不透明度，默认 0.3
+	Position string  `json:"position"` // 位置: c、tl、tc、tr、l、r、bl、bc、br，默认 c
+	Rotation float64 `json:"rotation"` // 旋转角度，默认文字水印 45、图片水印 0
+	Scale    float64 `json:"scale"`    // 相对页面的大小，默认 0.5
+	Color    string  `json:"color"`    // 文字颜色，默认 #808080
+}
+
+// Header 每页的页眉，显示报纸名称、日期和版次
+type Header struct {
+	// Format 页眉文字，支持 {paper}、{date}、{label}、{section}，默认为 "{paper} {date} 第{label}版"
+	Format   string  `json:"format"`
+	Position string  `json:"position"`  // 位置，默认 tc
+	FontSize int     `json:"font_size"` // 字号，默认 9
+	Opacity  float64 `json:"opacity"`   // 不透明度，默认 0.8
+	Color    string  `json:"color"`     // 文字颜色，默认 #404040
+}
+
+// StampFor 返回适用于报纸的水印和页眉配置，没有匹配的配置时返回 nil
+func (c *Config) StampFor(code, family string) *Stamp {
+	for i, s := range c.Stamps {
+		if len(s.Papers) == 0 && s.Family == "" {
+			return &c.Stamps[i]
+		}
+		for _, p := range s.Papers {
+			if p == code {
+				return &c.Stamps[i]
+			}
+		}
+		if s.Family != "" && s.Family == family {
+			return &c.Stamps[i]
+		}
+	}
+	return nil
+}
+
+// Paper 返回报纸的设置，没有配置时返回零值
+func (c *Config) Paper(code string) PaperConfig {
+	return c.Papers[code]
+}
+
+// Load 读取配置文件
+// 文件不存在时返回默认配置
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("读取配置文件失败: %v", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("解析配置文件 %s 失败: %v", path, err)
+		}
+	}
+
+	cfg.setDefaults()
+	return cfg, nil
+}
+
+// setDefaults 填充未配置的默认值
+func (c *Config) setDefaults() {
+	if c.StateDir == "" {
+		c.StateDir = ".papers"
+	}
+	if c.Schedule.CatchUpDays == 0 {
+		c.Schedule.CatchUpDays = 7
+	}
+	for i := range c.Retention.Rules {
+		if len(c.Retention.Rules[i].Weekdays) == 0 {
+			c.Retention.Rules[i].Weekdays = []string{"mon"}
+		}
+	}
+	for i := range c.Stamps {
+		if c.Stamps[i].Font == "" {
+			c.Stamps[i].Font = c.Font
+		}
+		c.Stamps[i].setDefaults()
+	}
+	for i := range c.Schedule.Jobs {
+		if c.Schedule.Jobs[i].Timezone == "" {
+			c.Schedule.Jobs[i].Timezone = "Asia/Shanghai"
+		}
+	}
+}
+
+// StatePath 返回状态目录下的文件路径
+func (c *Config) StatePath(name string) string {
+	return filepath.Join(c.StateDir, name)
+}
+
+// setDefaults 填充水印和页眉的默认值
+func (s *Stamp) setDefaults() {
+	if wm := s.Watermark; wm != nil {
+		if wm.Opacity == 0 {
+			wm.Opacity = 0.3
+		}
+		if wm.Position == "" {
+			wm.Position = "c"
+		}
+		if wm.Rotation == 0 && wm.Image == "" {
+			wm.Rotation = 45
+		}
+		if wm.Scale == 0 {
+			wm.Scale = 0.5
+		}
+		if wm.Color == "" {
+			wm.Color = "#808080"
+		}
+	}
+	if h := s.Header; h != nil {
+		if h.Format == "" {
+			h.Format = "{paper} {date} 第{label}版"
+		}
+		if h.Position == "" {
+			h.Position = "tc"
+		}
+		if h.FontSize == 0 {
+			h.FontSize = 9
+		}
+		if h.Opacity == 0 {
+			h.Opacity = 0.8
+		}
+		if h.Color == "" {
+			h.Color = "#404040"
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// weekdayNames 星期的配置名称
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseWeekday 解析配置中的星期名称，支持 mon、monday 等英文缩写或全称
+func ParseWeekday(s string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if len(name) > 3 {
+		name = name[:3]
+	}
+	if d, ok := weekdayNames[name]; ok {
+		return d, nil
+	}
+	return 0, fmt.Errorf("无效的星期: %s", s)
+}
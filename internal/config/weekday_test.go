@@ -0,0 +1,26 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWeekday(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Weekday
+		wantErr bool
+	}{
+		{in: "mon", want: time.Monday},
+		{in: "Friday", want: time.Friday},
+		{in: " SUN ", want: time.Sunday},
+		{in: "xyz", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseWeekday(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseWeekday(%q) = %v, %v, want %v, wantErr %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package crawler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// AssetKind 版面资源的类型
+type AssetKind int
+
+const (
+	// AssetPDF 远程PDF文件
+	AssetPDF AssetKind = iota
+	// AssetImage 远程图片，由爬虫下载后转换为PDF
+	AssetImage
+	// AssetFile 本地文件（PDF或图片）
+	AssetFile
+	// AssetBlob 内存中的文件内容（PDF或图片）
+	AssetBlob
+	// AssetTiles 缩放查看器的瓦片，由爬虫下载后拼接为一张图片
+	AssetTiles
+)
+
+// String 返回资源类型的名称
+func (k AssetKind) String() string {
+	switch k {
+	case AssetPDF:
+		return "pdf"
+	case AssetImage:
+		return "image"
+	case AssetFile:
+		return "file"
+	case AssetBlob:
+		return "blob"
+	case AssetTiles:
+		return "tiles"
+	}
+	return fmt.Sprintf("AssetKind(%d)", int(k))
+}
+
+// PageAsset 描述一个版面对应的资源
+// 获取器只负责发现资源，下载和图片转PDF由爬虫统一处理
+type PageAsset struct {
+	Kind    AssetKind
+	URL     string      // 远程资源地址（AssetPDF、AssetImage）
+	Path    string      // 本地文件路径（AssetFile）
+	Data    []byte      // 文件内容（AssetBlob）
+	Tiles   *TileSet    // 瓦片网格（AssetTiles）
+	Headers http.Header // 下载远程资源时附带的请求头，如 Referer
+
+	Label   string // 版次，如 01、A01，未知时为空
+	Section string // 版面名称，如 要闻、评论，未知时为空
+
+	Headlines []string // 版面上的文章标题，用于生成目录页
+}
+
+// NewPDFAsset 创建远程PDF资源
+func NewPDFAsset(url string) *PageAsset {
+	return &PageAsset{Kind: AssetPDF, URL: url}
+}
+
+// NewImageAsset 创建远程图片资源
+func NewImageAsset(url string) *PageAsset {
+	return &PageAsset{Kind: AssetImage, URL: url}
+}
+
+// NewFileAsset 创建本地文件资源
+func NewFileAsset(path string) *PageAsset {
+	return &PageAsset{Kind: AssetFile, Path: path}
+}
+
+// NewBlobAsset 创建内存资源
+func NewBlobAsset(data []byte) *PageAsset {
+	return &PageAsset{Kind: AssetBlob, Data: data}
+}
+
+// WithHeader 设置下载时附带的请求头，返回自身以便链式调用
+func (a *PageAsset) WithHeader(key, value string) *PageAsset {
+	if a.Headers == nil {
+		a.Headers = make(http.Header)
+	}
+	a.Headers.Set(key, value)
+	return a
+}
+
+// WithHeading 设置版次和版面名称，返回自身以便链式调用
+func (a *PageAsset) WithHeading(label, section string) *PageAsset {
+	a.Label = label
+	a.Section = section
+	return a
+}
+
+// WithHeadlines 设置版面上的文章标题，返回自身以便链式调用
+func (a *PageAsset) WithHeadlines(headlines []string) *PageAsset {
+	a.Headlines = headlines
+	return a
+}
+
+// Source 返回资源的来源描述，用于日志输出
+func (a *PageAsset) Source() string {
+	if origin := a.origin(); origin != "" {
+		return origin
+	}
+	return fmt.Sprintf("<%d bytes>", len(a.Data))
+}
+
+// origin 返回资源的地址或本地路径，内存中的数据返回空字符串
+func (a *PageAsset) origin() string {
+	switch a.Kind {
+	case AssetPDF, AssetImage:
+		return a.URL
+	case AssetFile:
+		return a.Path
+	case AssetTiles:
+		if level, err := a.Tiles.maxLevel(); err == nil {
+			return a.Tiles.TileURL(level.Zoom, 0, 0)
+		}
+	}
+	return ""
+}
+
+// read 读取资源内容
+func (a *PageAsset) read() ([]byte, error) {
+	switch a.Kind {
+	case AssetPDF, AssetImage:
+		resp, err := httpGet(a.URL, a.Headers)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		return io.ReadAll(resp.Body)
+	case AssetFile:
+		return os.ReadFile(a.Path)
+	case AssetBlob:
+		return a.Data, nil
+	case AssetTiles:
+		return a.Tiles.stitch(a.Headers)
+	}
+	return nil, fmt.Errorf("未知的资源类型: %v", a.Kind)
+}
+
+// size 获取资源大小，远程资源通过HEAD请求获取，不下载内容
+func (a *PageAsset) size() (int64, error) {
+	switch a.Kind {
+	case AssetPDF, AssetImage:
+		resp, err := httpDo(http.MethodHead, a.URL, a.Headers)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		if resp.ContentLength < 0 {
+			return 0, fmt.Errorf("服务器未返回文件大小")
+		}
+		return resp.ContentLength, nil
+	case AssetFile:
+		info, err := os.Stat(a.Path)
+		if err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	case AssetBlob:
+		return int64(len(a.Data)), nil
+	}
+	return 0, fmt.Errorf("%s 资源无法预先获取大小", a.Kind)
+}
+
+// isPDF 判断资源内容是否为PDF
+// 远程资源以类型为准，本地文件和内存数据根据文件头判断
+func (a *PageAsset) isPDF(data []byte) bool {
+	switch a.Kind {
+	case AssetPDF:
+		return true
+	case AssetImage, AssetTiles:
+		return false
+	}
+	return bytes.HasPrefix(data, []byte("%PDF-"))
+}
+
+// httpGet 发送带请求头的GET请求，非200状态码视为错误
+func httpGet(url string, headers http.Header) (*http.Response, error) {
+	return httpDo(http.MethodGet, url, headers)
+}
+
+// httpDo 发送带请求头的HTTP请求，非200状态码视为错误
+func httpDo(method, url string, headers http.Header) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range headers {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("HTTP状态码: %d", resp.StatusCode)
+	}
+
+	return resp, nil
+}
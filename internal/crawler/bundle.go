@@ -0,0 +1,190 @@
+package crawler
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Edition 合集中的一期报纸
+type Edition struct {
+	Paper string // 报纸代码
+	Path  string // 合并后的PDF文件
+}
+
+// BundleOptions 合集的参数
+type BundleOptions struct {
+	Title    string // 标题，默认为 报纸合集
+	Date     time.Time
+	Dividers bool   // 每份报纸前插入显示报纸名称的分隔页
+	Font     string // 分隔页使用的字体，需支持中文
+}
+
+// edition 合集中一期报纸的书签、页码标签和起始页
+type edition struct {
+	Edition
+	bookmarks []pdfcpu.Bookmark
+	labels    []string
+	start     int // 在合集中的起始页，从1开始
+}
+
+// Bundle 将多期报纸按顺序合并为一个PDF
+// 每份报纸一个顶层书签，原有的版面书签嵌套在其下，页码标签为 报纸名称 版次，只有一页时为报纸名称
+func Bundle(editions []Edition, out string, opts BundleOptions) error {
+	if len(editions) == 0 {
+		return fmt.Errorf("没有需要合并的报纸")
+	}
+
+	var fontName string
+	if opts.Dividers {
+		name, err := ResolveFont(opts.Font)
+		if err != nil {
+			return err
+		}
+		fontName = name
+	}
+
+	// 合并前读取各期的书签和页码标签，合并后按起始页重新生成
+	parts := make([]edition, 0, len(editions))
+	files := make([]string, 0, len(editions))
+	for _, e := range editions {
+		ctx, err := api.ReadContextFile(e.Path)
+		if err != nil {
+			return fmt.Errorf("读取 %s 失败: %v", e.Path, err)
+		}
+		bms, err := readOutline(ctx)
+		if err != nil {
+			return fmt.Errorf("读取 %s 的书签失败: %v", e.Path, err)
+		}
+		parts = append(parts, edition{Edition: e, bookmarks: bms, labels: readPageLabels(ctx)})
+		files = append(files, e.Path)
+	}
+
+	tmp := out + ".tmp"
+	if err := api.MergeCreateFile(files, tmp, false, model.NewDefaultConfiguration()); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("合并PDF失败: %v", err)
+	}
+	defer os.Remove(tmp)
+
+	ctx, err := api.ReadContextFile(tmp)
+	if err != nil {
+		return err
+	}
+
+	// 分隔页插入在每份报纸的第一页之前
+	offset := 0
+	if opts.Dividers {
+		offset = 1
+	}
+	before := make(types.IntSet)
+	page := 1
+	for i := range parts {
+		before[page] = true
+		parts[i].start = page + (i+1)*offset
+		page += len(parts[i].labels)
+	}
+	if opts.Dividers {
+		if err := addDividers(ctx, parts, fontName, opts.Date, before); err != nil {
+			return fmt.Errorf("生成分隔页失败: %v", err)
+		}
+	}
+
+	var bookmarks []pdfcpu.Bookmark
+	var labels, names []string
+	for _, p := range parts {
+		name := PaperName(p.Paper)
+		names = append(names, name)
+		if opts.Dividers {
+			labels = append(labels, name)
+		}
+
+		bm := pdfcpu.Bookmark{Title: name, PageFrom: p.start - offset}
+		bm.Kids = shiftBookmarks(p.bookmarks, p.start-1)
+		bookmarks = append(bookmarks, bm)
+
+		if len(p.labels) == 1 {
+			labels = append(labels, name)
+			continue
+		}
+		for _, l := range p.labels {
+			labels = append(labels, name+" "+l)
+		}
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = "报纸合集"
+	}
+	date := opts.Date.Format("2006-01-02")
+	m := Metadata{Title: title + " " + date, Subject: date, Keywords: names}
+	if err := setMetadata(ctx, m); err != nil {
+		return err
+	}
+	if err := addOutline(ctx, bookmarks); err != nil {
+		return fmt.Errorf("添加书签失败: %v", err)
+	}
+	if err := setPageLabels(ctx, labels); err != nil {
+		return fmt.Errorf("设置页码标签失败: %v", err)
+	}
+
+	if err := api.WriteContextFile(ctx, out+".new"); err != nil {
+		os.Remove(out + ".new")
+		return err
+	}
+	return os.Rename(out+".new", out)
+}
+
+// addDividers 在 before 中的每一页之前插入分隔页，显示报纸名称和日期
+func addDividers(ctx *model.Context, parts []edition, fontName string, date time.Time, before types.IntSet) error {
+	if err := ctx.InsertBlankPages(before, true); err != nil {
+		return err
+	}
+	ctx.PageCount += len(before)
+
+	dims, err := ctx.PageDims()
+	if err != nil {
+		return err
+	}
+	tw, err := newTextWriter(ctx, fontName)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range parts {
+		if err := checkFont(PaperName(p.Paper), fontName); err != nil {
+			return err
+		}
+		pageNr := p.start - 1
+		w, h := dims[pageNr-1].Width, dims[pageNr-1].Height
+		lines := []coverLine{
+			{text: PaperName(p.Paper), size: 40, center: true},
+			{text: date.Format("2006-01-02"), size: 18, center: true, gap: 16},
+			{text: fmt.Sprintf("共 %d 版", len(p.labels)), size: 14, center: true, gap: 8},
+		}
+		pages := layoutCover(lines, fontName, w, h)
+		if err := tw.write(pageNr, pages[0], w, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shiftBookmarks 复制书签并将页码后移 offset 页
+func shiftBookmarks(bms []pdfcpu.Bookmark, offset int) []pdfcpu.Bookmark {
+	shifted := make([]pdfcpu.Bookmark, 0, len(bms))
+	for _, bm := range bms {
+		shifted = append(shifted, pdfcpu.Bookmark{
+			Title:    strings.TrimSpace(bm.Title),
+			PageFrom: bm.PageFrom + offset,
+			Kids:     shiftBookmarks(bm.Kids, offset),
+		})
+	}
+	return shifted
+}
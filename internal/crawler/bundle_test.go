@@ -0,0 +1,150 @@
+package crawler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// writeEdition 生成 n 页的报纸，书签与合并后的报纸一致：第01版、第02版……
+// 书签通过 pdfcpu.AddBookmarks 添加，各期报纸的命名目标同名
+func writeEdition(t *testing.T, dir, paper string, titles ...string) Edition {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, paper+"_20251110.pdf")
+	writeTestPDF(t, path, len(titles), func(ctx *model.Context) error {
+		var bms []pdfcpu.Bookmark
+		for i, title := range titles {
+			bms = append(bms, pdfcpu.Bookmark{Title: title, PageFrom: i + 1})
+		}
+		return pdfcpu.AddBookmarks(ctx, bms, true)
+	})
+	return Edition{Paper: paper, Path: path}
+}
+
+// bookmarkPage 书签的标题和指向的页码
+type bookmarkPage struct {
+	title string
+	page  int
+}
+
+// flatten 按顺序展开书签，子书签标题前加上父书签的标题
+func flatten(bms []pdfcpu.Bookmark, prefix string) []bookmarkPage {
+	var pages []bookmarkPage
+	for _, bm := range bms {
+		title := prefix + bm.Title
+		pages = append(pages, bookmarkPage{title, bm.PageFrom})
+		pages = append(pages, flatten(bm.Kids, title+"/")...)
+	}
+	return pages
+}
+
+// outlineDicts 返回从 first 开始的所有书签字典，包括子书签
+func outlineDicts(t *testing.T, ctx *model.Context, first *types.IndirectRef) []types.Dict {
+	t.Helper()
+	var dicts []types.Dict
+	for ir := first; ir != nil; {
+		d, err := ctx.DereferenceDict(*ir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dicts = append(dicts, d)
+		dicts = append(dicts, outlineDicts(t, ctx, d.IndirectRefEntry("First"))...)
+		ir = d.IndirectRefEntry("Next")
+	}
+	return dicts
+}
+
+func TestBundleBookmarks(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		editions []Edition
+		opts     BundleOptions
+		want     []bookmarkPage
+		pages    int
+	}{
+		{
+			name: "合集中的同名版面",
+			editions: []Edition{
+				writeEdition(t, filepath.Join(dir, "a"), "rmrb", "第01版：要闻", "第02版", "第03版"),
+				writeEdition(t, filepath.Join(dir, "a"), "jksb", "第01版：要闻", "第02版"),
+			},
+			want: []bookmarkPage{
+				{"rmrb", 1}, {"rmrb/第01版：要闻", 1}, {"rmrb/第02版", 2}, {"rmrb/第03版", 3},
+				{"jksb", 4}, {"jksb/第01版：要闻", 4}, {"jksb/第02版", 5},
+			},
+			pages: 5,
+		},
+		{
+			name: "只有一份报纸",
+			editions: []Edition{
+				writeEdition(t, filepath.Join(dir, "b"), "rmrb", "第01版：要闻"),
+			},
+			want:  []bookmarkPage{{"rmrb", 1}, {"rmrb/第01版：要闻", 1}},
+			pages: 1,
+		},
+		{
+			name: "今日头版",
+			editions: []Edition{
+				writeEdition(t, filepath.Join(dir, "c"), "rmrb", "第01版：要闻"),
+				writeEdition(t, filepath.Join(dir, "c"), "jksb", "第01版：要闻"),
+				writeEdition(t, filepath.Join(dir, "c"), "fcyym", "第01版：要闻"),
+			},
+			opts: BundleOptions{Title: "今日头版"},
+			want: []bookmarkPage{
+				{"rmrb", 1}, {"rmrb/第01版：要闻", 1},
+				{"jksb", 2}, {"jksb/第01版：要闻", 2},
+				{"fcyym", 3}, {"fcyym/第01版：要闻", 3},
+			},
+			pages: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "papers_20251110.pdf")
+			tt.opts.Date = time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+			if err := Bundle(tt.editions, out, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, err := api.ReadContextFile(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ctx.PageCount != tt.pages {
+				t.Errorf("PageCount = %d, want %d", ctx.PageCount, tt.pages)
+			}
+			bms, err := readOutline(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// 书签直接指向页面，不依赖按标题查找的命名目标
+			for _, d := range outlineDicts(t, ctx, ctx.Outlines.IndirectRefEntry("First")) {
+				if _, ok := d["Dest"].(types.Array); !ok {
+					t.Errorf("bookmark %v uses named destination %v", d["Title"], d["Dest"])
+				}
+			}
+
+			got := flatten(bms, "")
+			if len(got) != len(tt.want) {
+				t.Fatalf("bookmarks = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("bookmark %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package crawler
+
+import "fmt"
+
+// CheckResult 版面可用性检查结果
+type CheckResult struct {
+	Available    bool  // 首个版面是否已发布
+	PageCount    int   // 总版数
+	AssetCount   int   // 找到资源的版面数
+	TotalSize    int64 // 资源总大小（字节）
+	UnknownSizes int   // 无法获取大小的版面数
+	Err          error // 不可用的原因
+}
+
+// Check 检查报纸是否已发布
+// 只请求版面页面，资源大小通过HEAD请求获取，不下载任何PDF
+func (c *Crawler) Check() *CheckResult {
+	result := &CheckResult{}
+
+	pageCount, err := c.Fetcher.GetPageCount(c.Fetcher.BuildURL(1))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Available = true
+	result.PageCount = pageCount
+
+	for i := 1; i <= pageCount; i++ {
+		asset, err := c.findAsset(i)
+		if err != nil {
+			fmt.Printf("第 %d 版未找到资源: %v\n", i, err)
+			continue
+		}
+		result.AssetCount++
+
+		size, err := asset.size()
+		if err != nil {
+			result.UnknownSizes++
+			continue
+		}
+		result.TotalSize += size
+	}
+
+	return result
+}
@@ -0,0 +1,236 @@
+package crawler
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
+	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// coverMargin 封面页边距占页面宽度的比例
+const coverMargin = 0.08
+
+// coverLine 封面和目录页上的一行文字
+type coverLine struct {
+	text   string
+	size   float64 // 以A4页面为基准的字号，按页面宽度缩放
+	center bool
+	indent float64 // 以A4页面为基准的缩进
+	gap    float64 // 与上一行之间额外的间距，以A4页面为基准
+	target int     // 点击后跳转的版面序号（从1开始），0 表示不跳转
+}
+
+// placedLine 排版后的一行文字
+type placedLine struct {
+	coverLine
+	fontSize int
+	x, y     float64
+}
+
+// coverLines 生成封面和目录的内容：报纸名称、日期、版数，以及每个版面的标题和文章标题
+func (c *Crawler) coverLines() []coverLine {
+	lines := []coverLine{
+		{text: PaperName(c.PaperType), size: 40, center: true, gap: 80},
+		{text: c.GetDateString(), size: 18, center: true, gap: 16},
+		{text: fmt.Sprintf("共 %d 版", len(c.pages)), size: 14, center: true, gap: 8},
+		{text: "目  录", size: 16, center: true, gap: 40},
+	}
+	for i, p := range c.pages {
+		lines = append(lines, coverLine{text: p.title(), size: 13, gap: 10, target: i + 1})
+		for _, h := range p.headlines {
+			lines = append(lines, coverLine{text: "· " + h, size: 10, indent: 16, gap: 2, target: i + 1})
+		}
+	}
+	return lines
+}
+
+// layoutCover 将文字排版到宽 w、高 h 的页面上，放不下时换到下一页
+func layoutCover(lines []coverLine, fontName string, w, h float64) [][]placedLine {
+	unit := w / types.PaperSize["A4"].Width
+	margin := w * coverMargin
+
+	var pages [][]placedLine
+	var page []placedLine
+	y := h - margin
+	for _, l := range lines {
+		size := max(int(math.Round(l.size*unit)), 1)
+		lineHeight := font.LineHeight(fontName, size)
+		gap := l.gap * unit
+
+		if len(page) > 0 && y-gap-lineHeight < margin {
+			pages = append(pages, page)
+			page = nil
+			y = h - margin
+		}
+		if len(page) > 0 {
+			y -= gap
+		}
+		y -= font.Ascent(fontName, size)
+
+		pl := placedLine{coverLine: l, fontSize: size, x: margin + l.indent*unit, y: y}
+		if l.center {
+			pl.x = w / 2
+		}
+		pl.text = fitText(l.text, fontName, size, w-margin-pl.x)
+		page = append(page, pl)
+
+		y -= font.Descent(fontName, size)
+	}
+	if len(page) > 0 {
+		pages = append(pages, page)
+	}
+	return pages
+}
+
+// fitText 截断超出宽度的文字
+func fitText(text, fontName string, size int, width float64) string {
+	if font.TextWidth(text, fontName, size) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for n := len(runes) - 1; n > 0; n-- {
+		s := string(runes[:n]) + "…"
+		if font.TextWidth(s, fontName, size) <= width {
+			return s
+		}
+	}
+	return ""
+}
+
+// addCover 在合并后的PDF前插入封面和目录，目录中的每个版面都可点击跳转
+// 封面与第一版页面大小相同，返回插入的页数
+func (c *Crawler) addCover(ctx *model.Context, fontName string) (int, error) {
+	dims, err := ctx.PageDims()
+	if err != nil {
+		return 0, err
+	}
+	w, h := dims[0].Width, dims[0].Height
+	pages := layoutCover(c.coverLines(), fontName, w, h)
+
+	for range pages {
+		if err := ctx.InsertBlankPages(types.IntSet{1: true}, true); err != nil {
+			return 0, err
+		}
+		ctx.PageCount++
+	}
+
+	tw, err := newTextWriter(ctx, fontName)
+	if err != nil {
+		return 0, err
+	}
+
+	links := make(map[int][]model.AnnotationRenderer)
+	for i, lines := range pages {
+		if err := tw.write(i+1, lines, w, h); err != nil {
+			return 0, err
+		}
+
+		for _, l := range lines {
+			if l.target == 0 {
+				continue
+			}
+			rect := types.NewRectangle(l.x, l.y-font.Descent(fontName, l.fontSize),
+				l.x+font.TextWidth(l.text, fontName, l.fontSize), l.y+font.Ascent(fontName, l.fontSize))
+			dest := &model.Destination{Typ: model.DestFit, PageNr: len(pages) + l.target}
+			id := fmt.Sprintf("toc%d_%d", i+1, len(links[i+1]))
+			links[i+1] = append(links[i+1], model.NewLinkAnnotation(*rect, nil, dest, "", id, 0, 0, model.BSSolid, nil, false))
+		}
+	}
+
+	if len(links) > 0 {
+		if _, err := pdfcpu.AddAnnotationsMap(ctx, links, false); err != nil {
+			return 0, fmt.Errorf("添加目录链接失败: %v", err)
+		}
+	}
+	return len(pages), nil
+}
+
+// textWriter 在插入的空白页上写入文字，所有页面共用一个字体资源
+type textWriter struct {
+	ctx      *model.Context
+	fontName string
+	fontKey  string
+	fontDict types.IndirectRef
+}
+
+// newTextWriter 创建字体资源
+func newTextWriter(ctx *model.Context, fontName string) (*textWriter, error) {
+	ir, err := pdffont.EnsureFontDict(ctx.XRefTable, fontName, "", "", false, nil)
+	if err != nil {
+		return nil, err
+	}
+	fm := model.FontMap{}
+	return &textWriter{ctx: ctx, fontName: fontName, fontKey: fm.EnsureKey(fontName), fontDict: *ir}, nil
+}
+
+// write 将排版后的文字写入第 pageNr 页，替换空白页的内容流和资源
+func (tw *textWriter) write(pageNr int, lines []placedLine, w, h float64) error {
+	mediaBox := types.RectForDim(w, h)
+
+	var buf bytes.Buffer
+	for _, l := range lines {
+		td := model.TextDescriptor{
+			Text:     l.text,
+			FontName: tw.fontName,
+			FontKey:  tw.fontKey,
+			Embed:    true,
+			FontSize: l.fontSize,
+			X:        l.x,
+			Y:        l.y,
+			HAlign:   types.AlignLeft,
+			Scale:    1,
+			ScaleAbs: true,
+			RMode:    draw.RMFill,
+			FillCol:  color.Black,
+		}
+		if l.center {
+			td.HAlign = types.AlignCenter
+		}
+		model.WriteMultiLine(tw.ctx.XRefTable, &buf, mediaBox, nil, td)
+	}
+
+	d, _, _, err := tw.ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+
+	ir := d.IndirectRefEntry("Contents")
+	if ir == nil {
+		return fmt.Errorf("第 %d 页没有内容流", pageNr)
+	}
+	entry, ok := tw.ctx.FindTableEntryForIndRef(ir)
+	if !ok {
+		return fmt.Errorf("第 %d 页的内容流无效", pageNr)
+	}
+
+	sd, err := tw.ctx.NewStreamDictForBuf(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+	entry.Object = *sd
+
+	d.Update("Resources", types.Dict{"Font": types.Dict{tw.fontKey: tw.fontDict}})
+	return nil
+}
+
+// coverLabels 返回封面和目录页的页码标签
+func coverLabels(n int) []string {
+	labels := make([]string, n)
+	for i := range labels {
+		labels[i] = "目录"
+	}
+	if n > 0 {
+		labels[0] = "封面"
+	}
+	return labels
+}
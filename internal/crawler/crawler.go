@@ -1,14 +1,16 @@
 package crawler
 
 import (
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
+	"papers/internal/catalog"
+	"papers/internal/config"
+
 	"github.com/PuerkitoBio/goquery"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
@@ -20,9 +22,21 @@ type PaperFetcher interface {
 	BuildURL(page int) string
 	// GetPageCount 获取总版数
 	GetPageCount(url string) (int, error)
-	// FindPDFURL 从页面中查找PDF下载链接
+	// FindAsset 从页面中查找版面资源（PDF、图片等）
 	// baseURL: 当前页面的URL，用于解析相对路径
-	FindPDFURL(doc *goquery.Document, baseURL string) (string, error)
+	FindAsset(doc *goquery.Document, baseURL string) (*PageAsset, error)
+}
+
+// pageFile 已下载的版面，单页PDF文件或待嵌入的图片
+type pageFile struct {
+	page   int
+	path   string     // 单页PDF文件路径
+	image  *imagePage // 图片版面，合并时直接嵌入，不生成单页PDF
+	source string     // 版面资源的地址或本地路径，内存中的数据为空
+
+	label     string   // 版次，如 01、A01
+	section   string   // 版面名称
+	headlines []string // 文章标题
 }
 
 // Crawler PDF爬虫基础结构
@@ -32,43 +46,86 @@ type Crawler struct {
 	MergedDir string
 	Date      time.Time
 	PageCount int
-	PDFFiles  []string
+	PDFFiles  []string     // 已下载的单页PDF临时文件
 	Fetcher   PaperFetcher // 特定报纸的获取逻辑
+
+	pages []pageFile // 按版面顺序记录已下载的版面
+
+	ImageOptions ImageOptions  // 图片版面转换为PDF的参数
+	Quality      QualityPreset // 图片版面的压缩预设
+	BytesSaved   int64         // 图片压缩节省的字节数
+
+	LastMissing time.Time // 等待发布时最后一次轮询到尚未发布的时间，未轮询到时为零值
+
+	CatalogPath string // 目录文件路径，为空时不记录
+
+	Stamp *config.Stamp // 水印和页眉，为 nil 时不添加
+
+	Cover     bool   // 在合并后的PDF前插入封面和目录
+	CoverFont string // 封面使用的字体，需支持中文
+
+	Selection *PageSelection // 只下载部分版面，为 nil 时下载所有版面
+
+	PrintLayout *PrintLayout // 合并后另外生成的打印版，为 nil 时不生成
+
+	MobileLayout *MobileLayout // 合并后另外生成的手机阅读版，为 nil 时不生成
 }
 
-// NewCrawler 创建新的爬虫实例
-// fetcher: 特定报纸的获取逻辑实现
-// dateStr: 可选的日期字符串，格式为 "2006-01-02" (如 "2025-11-10")
+// ParseDate 解析日期字符串，格式为 "2006-01-02" (如 "2025-11-10")
 // 如果为空字符串，则使用当前东8区时间
-func NewCrawler(paperType string, fetcher PaperFetcher, dateStr string) (*Crawler, error) {
-	var targetDate time.Time
+func ParseDate(dateStr string) (time.Time, error) {
 	loc, _ := time.LoadLocation("Asia/Shanghai")
 
 	if dateStr == "" {
 		// 使用当前东8区时间
-		targetDate = time.Now().In(loc)
-	} else {
-		// 解析传入的日期字符串
-		parsedDate, err := time.Parse("2006-01-02", dateStr)
-		if err != nil {
-			return nil, fmt.Errorf("日期格式错误，应为 YYYY-MM-DD 格式: %v", err)
-		}
-		// 转换为东8区时间
-		targetDate = parsedDate.In(loc)
+		return time.Now().In(loc), nil
+	}
+
+	// 解析传入的日期字符串
+	parsedDate, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("日期格式错误，应为 YYYY-MM-DD 格式: %v", err)
+	}
+	// 转换为东8区时间
+	return parsedDate.In(loc), nil
+}
+
+// NewCrawler 创建新的爬虫实例
+// fetcher: 特定报纸的获取逻辑实现
+// dateStr: 可选的日期字符串，格式为 "2006-01-02" (如 "2025-11-10")
+// 如果为空字符串，则使用当前东8区时间
+func NewCrawler(paperType string, fetcher PaperFetcher, dateStr string) (*Crawler, error) {
+	targetDate, err := ParseDate(dateStr)
+	if err != nil {
+		return nil, err
 	}
 
+	return NewCrawlerForDate(paperType, fetcher, targetDate), nil
+}
+
+// NewCrawlerForDate 创建指定日期的爬虫实例
+func NewCrawlerForDate(paperType string, fetcher PaperFetcher, targetDate time.Time) *Crawler {
 	// 创建日期目录路径
 	dateDir := targetDate.Format("20060102")
 	mergedDir := filepath.Join("dist", dateDir)
 
+	// 图片类报纸可以由获取器指定转换参数
+	imageOptions := DefaultImageOptions()
+	if p, ok := fetcher.(ImageOptionsProvider); ok {
+		imageOptions = p.ImageOptions()
+	}
+
 	return &Crawler{
-		PaperType: paperType,
-		OutputDir: "web/files",
-		MergedDir: mergedDir,
-		Date:      targetDate,
-		PDFFiles:  make([]string, 0),
-		Fetcher:   fetcher,
-	}, nil
+		PaperType:    paperType,
+		OutputDir:    "web/files",
+		MergedDir:    mergedDir,
+		Date:         targetDate,
+		PDFFiles:     make([]string, 0),
+		Fetcher:      fetcher,
+		ImageOptions: imageOptions,
+		Quality:      qualityPresets["archive"],
+		CatalogPath:  catalog.DefaultPath,
+	}
 }
 
 // Run 执行爬虫任务
@@ -90,27 +147,102 @@ func (c *Crawler) Run() error {
 	fmt.Printf("共有 %d 版\n", pageCount)
 
 	// 下载所有版面的PDF
+	if c.Selection != nil {
+		fmt.Printf("只下载: %s\n", c.Selection)
+	}
 	for i := 1; i <= pageCount; i++ {
+		if c.Selection != nil && !c.Selection.needsPage(i) {
+			continue
+		}
 		if err := c.downloadPDF(i); err != nil {
-			fmt.Printf("下载第 %d 版失败: %v\n", i, err)
+			if !errors.Is(err, errPageSkipped) {
+				fmt.Printf("下载第 %d 版失败: %v\n", i, err)
+			}
 			continue
 		}
 		fmt.Printf("成功下载第 %d 版\n", i)
 	}
 
 	// 合并PDF
-	if len(c.PDFFiles) > 0 {
+	if len(c.pages) > 0 {
 		if err := c.mergePDFs(); err != nil {
 			return fmt.Errorf("合并PDF失败: %v", err)
 		}
 		fmt.Println("PDF合并完成!")
+
+		if err := c.annotate(c.MergedFilePath()); err != nil {
+			fmt.Printf("警告: 写入文档信息、书签、页码标签、水印或封面失败: %v\n", err)
+		}
+
+		if c.PrintLayout != nil {
+			out, err := Impose(c.MergedFilePath(), *c.PrintLayout)
+			if err != nil {
+				fmt.Printf("警告: 生成打印版失败: %v\n", err)
+			} else {
+				fmt.Printf("打印版保存至: %s\n", out)
+			}
+		}
+
+		if c.MobileLayout != nil {
+			out, err := Mobile(c.MergedFilePath(), *c.MobileLayout)
+			if err != nil {
+				fmt.Printf("警告: 生成手机阅读版失败: %v\n", err)
+			} else {
+				fmt.Printf("手机阅读版保存至: %s\n", out)
+			}
+		}
+	} else if c.Selection != nil {
+		return fmt.Errorf("没有下载到符合 %s 的版面", c.Selection)
 	} else {
 		return fmt.Errorf("没有下载到任何PDF文件")
 	}
 
+	// 目录只是索引，写入失败不影响已下载的文件
+	if err := c.recordCatalog(); err != nil {
+		fmt.Printf("警告: 写入目录失败: %v\n", err)
+	}
+
 	return nil
 }
 
+// recordCatalog 将合并后的文件记录到目录
+func (c *Crawler) recordCatalog() error {
+	// 只下载部分版面时不是完整的一期，不记录到目录
+	if c.CatalogPath == "" || c.Selection != nil {
+		return nil
+	}
+
+	entry, err := catalog.NewEntry(c.PaperType, c.Date, c.MergedFilePath())
+	if err != nil {
+		return err
+	}
+	entry.PageCount = c.PageCount
+	entry.MissingPages = c.MissingPages()
+	for _, p := range c.pages {
+		if p.source != "" {
+			entry.Sources = append(entry.Sources, p.source)
+		}
+	}
+
+	return catalog.Append(c.CatalogPath, entry)
+}
+
+// MissingPages 返回下载失败的版面
+func (c *Crawler) MissingPages() []int {
+	downloaded := make(map[int]bool, len(c.pages))
+	for _, p := range c.pages {
+		downloaded[p.page] = true
+	}
+
+	var missing []int
+	for i := 1; i <= c.PageCount; i++ {
+		if !downloaded[i] {
+			missing = append(missing, i)
+		}
+	}
+	return missing
+}
+
 // createDirectories 创建必要的目录
 func (c *Crawler) createDirectories() error {
 	dirs := []string{c.OutputDir, c.MergedDir}
@@ -124,107 +256,103 @@ func (c *Crawler) createDirectories() error {
 
 // downloadPDF 下载指定版面的PDF
 func (c *Crawler) downloadPDF(page int) error {
+	asset, err := c.findAsset(page)
+	if err != nil {
+		return err
+	}
+
+	if c.Selection != nil && !c.Selection.Match(page, asset.Section) {
+		return errPageSkipped
+	}
+
+	fmt.Printf("第 %d 版资源 (%s): %s\n", page, asset.Kind, asset.Source())
+
+	// 下载资源并保存为PDF文件
+	return c.saveAsset(asset, page)
+}
+
+// findAsset 请求指定版面的页面并查找版面资源
+func (c *Crawler) findAsset(page int) (*PageAsset, error) {
 	url := c.Fetcher.BuildURL(page)
 
 	resp, err := http.Get(url)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP状态码: %d", resp.StatusCode)
+		return nil, fmt.Errorf("HTTP状态码: %d", resp.StatusCode)
 	}
 
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// 使用特定报纸的逻辑查找PDF链接，传入当前页面URL用于解析相对路径
-	pdfURL, err := c.Fetcher.FindPDFURL(doc, url)
+	// 使用特定报纸的逻辑查找版面资源，传入当前页面URL用于解析相对路径
+	return c.Fetcher.FindAsset(doc, url)
+}
+
+// saveAsset 读取版面资源并保存
+// PDF资源写入临时文件，图片资源保留在内存中，合并时一次性嵌入
+func (c *Crawler) saveAsset(asset *PageAsset, page int) error {
+	data, err := asset.read()
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("第 %d 版 PDF URL: %s\n", page, pdfURL)
-
-	// 下载PDF文件
-	return c.savePDF(pdfURL, page)
-}
-
-// savePDF 保存PDF文件
-func (c *Crawler) savePDF(pdfURL string, page int) error {
-	// 生成文件名: paperType_日期_版号.pdf
-	filename := fmt.Sprintf("%s_%s_%02d.pdf", c.PaperType, c.Date.Format("20060102"), page)
-	destPath := filepath.Join(c.OutputDir, filename)
-
-	// 检查是否是本地文件（XAWB的情况）
-	if strings.HasPrefix(pdfURL, "file://") {
-		// 本地文件，直接移动或复制
-		srcPath := strings.TrimPrefix(pdfURL, "file://")
-
-		// 复制文件
-		srcFile, err := os.Open(srcPath)
+	if !asset.isPDF(data) {
+		img, err := c.prepareImage(data)
 		if err != nil {
 			return err
 		}
-		defer srcFile.Close()
-
-		destFile, err := os.Create(destPath)
-		if err != nil {
-			return err
-		}
-		defer destFile.Close()
-
-		_, err = io.Copy(destFile, srcFile)
-		if err != nil {
-			return err
-		}
-
-		// 删除临时文件
-		os.Remove(srcPath)
-
-		c.PDFFiles = append(c.PDFFiles, destPath)
+		c.pages = append(c.pages, pageFile{page: page, image: img, source: asset.origin(), label: asset.Label, section: asset.Section, headlines: asset.Headlines})
 		return nil
 	}
 
-	// 网络URL，正常下载
-	resp, err := http.Get(pdfURL)
-	if err != nil {
+	destPath := c.pageFilePath(page)
+	if err := os.WriteFile(destPath, data, 0644); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP状态码: %d", resp.StatusCode)
-	}
+	c.PDFFiles = append(c.PDFFiles, destPath)
+	c.pages = append(c.pages, pageFile{page: page, path: destPath, source: asset.origin(), label: asset.Label, section: asset.Section, headlines: asset.Headlines})
+	return nil
+}
+
+// pageFilePath 单页PDF文件路径: paperType_日期_版号.pdf
+func (c *Crawler) pageFilePath(page int) string {
+	filename := fmt.Sprintf("%s_%s_%02d.pdf", c.PaperType, c.Date.Format("20060102"), page)
+	return filepath.Join(c.OutputDir, filename)
+}
 
-	// 创建文件
-	out, err := os.Create(destPath)
+// prepareImage 解析版面图片并按质量预设压缩，累计节省的字节数
+func (c *Crawler) prepareImage(data []byte) (*imagePage, error) {
+	img, err := decodeImagePage(data)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer out.Close()
 
-	// 写入内容
-	_, err = io.Copy(out, resp.Body)
-	if err != nil {
-		return err
+	if err := c.Quality.apply(img, c.ImageOptions); err != nil {
+		return nil, fmt.Errorf("压缩图片失败: %v", err)
 	}
 
-	c.PDFFiles = append(c.PDFFiles, destPath)
-	return nil
+	if !c.Quality.isLossless() {
+		c.BytesSaved += int64(len(data) - len(img.data))
+	}
+
+	return img, nil
 }
 
-// mergePDFs 合并所有下载的PDF文件
+// mergePDFs 合并所有下载的版面
+// 全部为图片版面时直接一次性生成合并文件，否则先将图片写为单页PDF再合并
 func (c *Crawler) mergePDFs() error {
-	if len(c.PDFFiles) == 0 {
+	if len(c.pages) == 0 {
 		return fmt.Errorf("没有PDF文件需要合并")
 	}
 
-	// 输出文件名: paperType_日期.pdf
-	outputFile := filepath.Join(c.MergedDir, fmt.Sprintf("%s_%s.pdf", c.PaperType, c.Date.Format("20060102")))
+	outputFile := c.MergedFilePath()
 
 	// 如果输出文件已存在，先删除（确保可以覆盖）
 	if _, err := os.Stat(outputFile); err == nil {
@@ -234,6 +362,28 @@ func (c *Crawler) mergePDFs() error {
 		}
 	}
 
+	if images := c.imagePages(); len(images) == len(c.pages) {
+		if err := writeImagePDFFile(outputFile, images, c.ImageOptions); err != nil {
+			return fmt.Errorf("生成图片PDF失败: %v", err)
+		}
+		fmt.Printf("合并后的文件保存至: %s\n", outputFile)
+		return nil
+	}
+
+	// 混合版面：图片写为单页PDF后按版面顺序合并
+	files := make([]string, 0, len(c.pages))
+	for _, p := range c.pages {
+		if p.image != nil {
+			path := c.pageFilePath(p.page)
+			if err := writeImagePDFFile(path, []*imagePage{p.image}, c.ImageOptions); err != nil {
+				return fmt.Errorf("转换第 %d 版图片为PDF失败: %v", p.page, err)
+			}
+			c.PDFFiles = append(c.PDFFiles, path)
+			p.path = path
+		}
+		files = append(files, p.path)
+	}
+
 	// 使用pdfcpu合并PDF
 	conf := model.NewDefaultConfiguration()
 
@@ -242,7 +392,7 @@ func (c *Crawler) mergePDFs() error {
 	time.Sleep(5 * time.Second)
 
 	// MergeCreateFile参数: inputFiles, outputFile, dividerPage(是否插入分隔页), config
-	err := api.MergeCreateFile(c.PDFFiles, outputFile, false, conf)
+	err := api.MergeCreateFile(files, outputFile, false, conf)
 	if err != nil {
 		return err
 	}
@@ -264,6 +414,27 @@ func (c *Crawler) mergePDFs() error {
 	return nil
 }
 
+// MergedFilePath 合并后的PDF文件路径: MergedDir/paperType_日期.pdf
+// 只下载部分版面时文件名带有选择条件，如 paperType_日期_p1-4_评论.pdf
+func (c *Crawler) MergedFilePath() string {
+	name := fmt.Sprintf("%s_%s", c.PaperType, c.Date.Format("20060102"))
+	if c.Selection != nil {
+		name += "_" + c.Selection.fileSuffix()
+	}
+	return filepath.Join(c.MergedDir, name+".pdf")
+}
+
+// imagePages 返回所有图片版面
+func (c *Crawler) imagePages() []*imagePage {
+	var images []*imagePage
+	for _, p := range c.pages {
+		if p.image != nil {
+			images = append(images, p.image)
+		}
+	}
+	return images
+}
+
 // GetDateString 获取日期字符串（用于测试）
 func (c *Crawler) GetDateString() string {
 	return c.Date.Format("2006-01-02")
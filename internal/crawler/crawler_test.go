@@ -0,0 +1,52 @@
+package crawler
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"papers/internal/catalog"
+)
+
+func TestRecordCatalogSources(t *testing.T) {
+	dir := t.TempDir()
+	c := &Crawler{
+		PaperType:   "rmrb",
+		MergedDir:   dir,
+		Date:        time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC),
+		PageCount:   4,
+		CatalogPath: filepath.Join(dir, "catalog.jsonl"),
+	}
+	if err := os.WriteFile(c.MergedFilePath(), []byte("%PDF-1.7"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	assets := []*PageAsset{
+		NewPDFAsset("http://paper.people.com.cn/rmrb/1.pdf"),
+		NewBlobAsset(make([]byte, 1800)),
+		NewFileAsset("/tmp/rmrb/3.pdf"),
+		NewImageAsset("https://paper.people.com.cn/rmrb/4.jpg"),
+	}
+	for i, a := range assets {
+		c.pages = append(c.pages, pageFile{page: i + 1, source: a.origin()})
+	}
+	if err := c.recordCatalog(); err != nil {
+		t.Fatal(err)
+	}
+
+	cat, err := catalog.Load(c.CatalogPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, ok := cat.Lookup("rmrb", "2025-11-10")
+	if !ok {
+		t.Fatal("entry not recorded")
+	}
+	// 内存中的数据没有地址，不记录占位描述
+	want := []string{"http://paper.people.com.cn/rmrb/1.pdf", "/tmp/rmrb/3.pdf", "https://paper.people.com.cn/rmrb/4.jpg"}
+	if !reflect.DeepEqual(e.Sources, want) {
+		t.Errorf("Sources = %v, want %v", e.Sources, want)
+	}
+}
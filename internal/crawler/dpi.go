@@ -0,0 +1,151 @@
+package crawler
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// minTrustedDPI 低于或等于该值的分辨率视为未知
+// 很多图片写入的72DPI只是编码器的默认值，并不代表实际扫描分辨率
+const minTrustedDPI = 72
+
+// imageDPI 读取图片中记录的分辨率，无法确定时返回0
+func imageDPI(data []byte, format string) float64 {
+	var dpi float64
+	switch format {
+	case "jpeg":
+		dpi = jpegDPI(data)
+	case "png":
+		dpi = pngDPI(data)
+	case "tiff":
+		dpi = tiffDPI(data)
+	}
+	if dpi <= minTrustedDPI {
+		return 0
+	}
+	return dpi
+}
+
+// jpegDPI 从JFIF APP0段读取分辨率
+func jpegDPI(data []byte) float64 {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 0
+		}
+		marker := data[i+1]
+		// SOS之后是图像数据，不再有APP段
+		if marker == 0xDA {
+			return 0
+		}
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+		seg := data[i+4:]
+		if size < 2 || len(seg) < size-2 {
+			return 0
+		}
+		seg = seg[:size-2]
+
+		// APP0: "JFIF\0" 版本(2) 单位(1) X密度(2) Y密度(2)
+		if marker == 0xE0 && len(seg) >= 12 && bytes.HasPrefix(seg, []byte("JFIF\x00")) {
+			density := float64(binary.BigEndian.Uint16(seg[8:]))
+			switch seg[7] {
+			case 1: // 每英寸
+				return density
+			case 2: // 每厘米
+				return density * 2.54
+			}
+			return 0
+		}
+
+		i += 2 + size
+	}
+
+	return 0
+}
+
+// pngDPI 从pHYs块读取分辨率
+func pngDPI(data []byte) float64 {
+	const sigLen = 8
+	for i := sigLen; i+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		typ := string(data[i+4 : i+8])
+		if typ == "IDAT" || typ == "IEND" {
+			return 0
+		}
+		body := data[i+8:]
+		if len(body) < length {
+			return 0
+		}
+
+		// pHYs: X像素数(4) Y像素数(4) 单位(1)，单位1表示每米
+		if typ == "pHYs" && length >= 9 {
+			if body[8] == 1 {
+				return float64(binary.BigEndian.Uint32(body)) * 0.0254
+			}
+			return 0
+		}
+
+		// 长度(4) + 类型(4) + 数据 + CRC(4)
+		i += 12 + length
+	}
+
+	return 0
+}
+
+// tiffDPI 从第一个IFD的XResolution和ResolutionUnit标签读取分辨率
+func tiffDPI(data []byte) float64 {
+	if len(data) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(data[4:]))
+	if ifd < 8 || ifd+2 > len(data) {
+		return 0
+	}
+
+	var resolution float64
+	unit := uint16(2) // 默认单位为英寸
+	count := int(order.Uint16(data[ifd:]))
+	for n := 0; n < count; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(data) {
+			break
+		}
+		tag := order.Uint16(data[entry:])
+		switch tag {
+		case 282: // XResolution, RATIONAL
+			offset := int(order.Uint32(data[entry+8:]))
+			if offset+8 > len(data) {
+				return 0
+			}
+			num := order.Uint32(data[offset:])
+			den := order.Uint32(data[offset+4:])
+			if den != 0 {
+				resolution = float64(num) / float64(den)
+			}
+		case 296: // ResolutionUnit, SHORT
+			unit = order.Uint16(data[entry+8:])
+		}
+	}
+
+	switch unit {
+	case 2:
+		return resolution
+	case 3:
+		return resolution * 2.54
+	}
+	return 0
+}
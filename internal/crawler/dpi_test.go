@@ -0,0 +1,97 @@
+package crawler
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// jfif 生成只包含JFIF APP0段的JPEG文件头
+func jfif(unit byte, density uint16) []byte {
+	var b bytes.Buffer
+	b.Write([]byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10})
+	b.WriteString("JFIF\x00")
+	b.Write([]byte{1, 1, unit})
+	binary.Write(&b, binary.BigEndian, density)
+	binary.Write(&b, binary.BigEndian, density)
+	b.Write([]byte{0, 0, 0xFF, 0xDA})
+	return b.Bytes()
+}
+
+// pngChunks 生成PNG文件头和指定的数据块，CRC不参与解析，填0
+func pngChunks(chunks ...[]byte) []byte {
+	b := bytes.NewBufferString("\x89PNG\r\n\x1a\n")
+	for _, c := range chunks {
+		b.Write(c)
+	}
+	return b.Bytes()
+}
+
+func pngChunk(typ string, body []byte) []byte {
+	var b bytes.Buffer
+	binary.Write(&b, binary.BigEndian, uint32(len(body)))
+	b.WriteString(typ)
+	b.Write(body)
+	b.Write(make([]byte, 4))
+	return b.Bytes()
+}
+
+func pHYs(ppm uint32, unit byte) []byte {
+	var b bytes.Buffer
+	binary.Write(&b, binary.BigEndian, ppm)
+	binary.Write(&b, binary.BigEndian, ppm)
+	b.WriteByte(unit)
+	return pngChunk("pHYs", b.Bytes())
+}
+
+// tiffHeader 生成只包含XResolution和ResolutionUnit的小端TIFF文件头
+func tiffHeader(num, den uint32, unit uint16) []byte {
+	var b bytes.Buffer
+	le := binary.LittleEndian
+	b.WriteString("II*\x00")
+	binary.Write(&b, le, uint32(8))
+	binary.Write(&b, le, uint16(2))
+	// XResolution 的值在IFD之后: 8 + 2 + 2*12 + 4 = 38
+	binary.Write(&b, le, []uint16{282, 5})
+	binary.Write(&b, le, []uint32{1, 38})
+	binary.Write(&b, le, []uint16{296, 3})
+	binary.Write(&b, le, []uint32{1, uint32(unit)})
+	binary.Write(&b, le, uint32(0))
+	binary.Write(&b, le, []uint32{num, den})
+	return b.Bytes()
+}
+
+func TestImageDPI(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		format string
+		want   float64
+	}{
+		{"jpeg 每英寸", jfif(1, 300), "jpeg", 300},
+		{"jpeg 每厘米", jfif(2, 100), "jpeg", 254},
+		{"jpeg 无单位", jfif(0, 1), "jpeg", 0},
+		{"jpeg 72DPI视为未知", jfif(1, 72), "jpeg", 0},
+		{"jpeg 截断", jfif(1, 300)[:10], "jpeg", 0},
+		{"jpeg 非JPEG", []byte("not a jpeg"), "jpeg", 0},
+		{"png 每米", pngChunks(pngChunk("IHDR", make([]byte, 13)), pHYs(11811, 1)), "png", 11811 * 0.0254},
+		{"png 无单位", pngChunks(pHYs(11811, 0)), "png", 0},
+		{"png 在IDAT之后", pngChunks(pngChunk("IDAT", nil), pHYs(11811, 1)), "png", 0},
+		{"png 无pHYs", pngChunks(pngChunk("IHDR", make([]byte, 13)), pngChunk("IEND", nil)), "png", 0},
+		{"png 截断", pngChunks(pHYs(11811, 1))[:20], "png", 0},
+		{"tiff 每英寸", tiffHeader(600, 2, 2), "tiff", 300},
+		{"tiff 每厘米", tiffHeader(100, 1, 3), "tiff", 254},
+		{"tiff 无单位", tiffHeader(300, 1, 1), "tiff", 0},
+		{"tiff 截断", tiffHeader(300, 1, 2)[:30], "tiff", 0},
+		{"其他格式", jfif(1, 300), "gif", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := imageDPI(tt.data, tt.format)
+			if d := got - tt.want; d > 0.001 || d < -0.001 {
+				t.Errorf("imageDPI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package crawler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"golang.org/x/image/font/sfnt"
+)
+
+// defaultFont 未指定字体时使用的pdfcpu内置字体，不支持中文
+const defaultFont = "Helvetica"
+
+// fontDir 安装字体的目录，位于状态目录下，由 ApplyConfig 设置
+// 不使用pdfcpu的用户字体目录，避免修改用户的全局配置
+var fontDir = filepath.Join(".papers", "fonts")
+
+// ResolveFont 返回pdfcpu可用的字体名
+// name 为字体文件路径（ttf、ttc、otf）时，首次使用会安装到状态目录下的 fonts 中；
+// 否则视为pdfcpu内置字体或已安装到该目录的字体名
+func ResolveFont(name string) (string, error) {
+	if name == "" {
+		return defaultFont, nil
+	}
+	if err := useFontDir(); err != nil {
+		return "", fmt.Errorf("加载字体目录 %s 失败: %v", fontDir, err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(name))
+	if ext != ".ttf" && ext != ".ttc" && ext != ".otf" {
+		if !font.IsCoreFont(name) && !fontInstalled(name) {
+			return "", fmt.Errorf("字体 %s 未安装，请指定字体文件路径", name)
+		}
+		return name, nil
+	}
+
+	psName, err := postScriptName(name)
+	if err != nil {
+		return "", fmt.Errorf("读取字体 %s 失败: %v", name, err)
+	}
+	if fontInstalled(psName) {
+		return psName, nil
+	}
+
+	fmt.Printf("安装字体 %s 到 %s\n", psName, fontDir)
+	if ext == ".ttc" {
+		err = font.InstallTrueTypeCollection(fontDir, name)
+	} else {
+		err = font.InstallTrueTypeFont(fontDir, name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("安装字体失败: %v", err)
+	}
+	if err := font.LoadUserFonts(); err != nil {
+		return "", fmt.Errorf("加载字体失败: %v", err)
+	}
+	if !fontInstalled(psName) || !font.SupportedFont(psName) {
+		return "", fmt.Errorf("字体 %s 安装后不可用", psName)
+	}
+	return psName, nil
+}
+
+// useFontDir 让pdfcpu从 fontDir 读取字体
+// 先加载pdfcpu的默认配置，否则之后首次加载配置时会改回pdfcpu的用户字体目录
+func useFontDir() error {
+	model.NewDefaultConfiguration()
+	if font.UserFontDir == fontDir {
+		return nil
+	}
+	if err := os.MkdirAll(fontDir, 0755); err != nil {
+		return err
+	}
+	font.UserFontDir = fontDir
+	return font.LoadUserFonts()
+}
+
+// fontInstalled 判断字体是否已安装到 fontDir
+func fontInstalled(name string) bool {
+	_, err := os.Stat(filepath.Join(fontDir, name+".gob"))
+	return err == nil
+}
+
+// postScriptName 读取字体文件的PostScript名称，字体集合取第一个字体
+// pdfcpu以该名称保存已安装的字体
+func postScriptName(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	var f *sfnt.Font
+	if strings.EqualFold(filepath.Ext(path), ".ttc") {
+		c, err := sfnt.ParseCollection(data)
+		if err != nil {
+			return "", err
+		}
+		if f, err = c.Font(0); err != nil {
+			return "", err
+		}
+	} else if f, err = sfnt.Parse(data); err != nil {
+		return "", err
+	}
+
+	return f.Name(nil, sfnt.NameIDPostScript)
+}
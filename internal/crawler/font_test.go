@@ -0,0 +1,59 @@
+package crawler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestResolveFont(t *testing.T) {
+	dir := t.TempDir()
+	fontDir = filepath.Join(dir, "state", "fonts")
+	t.Cleanup(func() { fontDir = filepath.Join(".papers", "fonts") })
+
+	ttf := filepath.Join(dir, "Go-Regular.ttf")
+	if err := os.WriteFile(ttf, goregular.TTF, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		font    string
+		want    string
+		wantErr bool
+	}{
+		{name: "默认字体", font: "", want: defaultFont},
+		{name: "内置字体", font: "Courier", want: "Courier"},
+		{name: "未安装的字体", font: "NoSuchFont", wantErr: true},
+		{name: "字体文件不存在", font: filepath.Join(dir, "missing.ttf"), wantErr: true},
+		{name: "安装字体文件", font: ttf, want: "GoRegular"},
+		{name: "已安装的字体文件", font: ttf, want: "GoRegular"},
+		{name: "已安装的字体名", font: "GoRegular", want: "GoRegular"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveFont(tt.font)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveFont(%q) error = %v, wantErr %v", tt.font, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveFont(%q) = %q, want %q", tt.font, got, tt.want)
+			}
+		})
+	}
+
+	// 字体安装在状态目录下，pdfcpu从该目录读取
+	if _, err := os.Stat(filepath.Join(fontDir, "GoRegular.gob")); err != nil {
+		t.Errorf("font not installed into the state dir: %v", err)
+	}
+	if font.UserFontDir != fontDir {
+		t.Errorf("pdfcpu font dir = %s, want %s", font.UserFontDir, fontDir)
+	}
+	if _, err := font.Read("GoRegular"); err != nil {
+		t.Errorf("pdfcpu cannot read the installed font: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package crawler
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// headingPattern 版面标题，如 "第01版：要闻"、"第A01版:评论"、"第 5 版 副刊"
+var headingPattern = regexp.MustCompile(`第\s*([A-Za-z]*\d+)\s*版\s*[:：]?\s*([^\s|_\-—]*)`)
+
+// defaultHeadingSelectors 常见数字报系统中显示当前版面标题的元素
+var defaultHeadingSelectors = []string{"p.left.ban", ".paper-bot .ban", "title"}
+
+// defaultHeadlineSelectors 常见数字报系统中当前版面的文章标题列表
+var defaultHeadlineSelectors = []string{".news-list li a", "#titleList li a", "ul.news-list a"}
+
+// ParsePageHeading 解析版面标题，返回版次和版面名称
+func ParsePageHeading(s string) (label, section string, ok bool) {
+	m := headingPattern.FindStringSubmatch(s)
+	if m == nil {
+		return "", "", false
+	}
+	return strings.ToUpper(m[1]), strings.TrimSpace(m[2]), true
+}
+
+// FindPageHeading 在版面页面中查找当前版面的版次和名称
+// 依次尝试 selectors 和常见的标题元素，都找不到时返回空字符串
+func FindPageHeading(doc *goquery.Document, selectors ...string) (label, section string) {
+	for _, sel := range append(selectors, defaultHeadingSelectors...) {
+		var ok bool
+		doc.Find(sel).EachWithBreak(func(i int, s *goquery.Selection) bool {
+			label, section, ok = ParsePageHeading(s.Text())
+			return !ok
+		})
+		if ok {
+			return label, section
+		}
+	}
+	return "", ""
+}
+
+// FindHeadlines 在版面页面中查找当前版面的文章标题
+// 依次尝试 selectors 和常见的标题列表，返回第一个找到的列表，去除空白和重复的标题
+func FindHeadlines(doc *goquery.Document, selectors ...string) []string {
+	for _, sel := range append(selectors, defaultHeadlineSelectors...) {
+		var headlines []string
+		seen := make(map[string]bool)
+		doc.Find(sel).Each(func(i int, s *goquery.Selection) {
+			title := strings.Join(strings.Fields(s.Text()), " ")
+			if title != "" && !seen[title] {
+				seen[title] = true
+				headlines = append(headlines, title)
+			}
+		})
+		if len(headlines) > 0 {
+			return headlines
+		}
+	}
+	return nil
+}
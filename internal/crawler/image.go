@@ -0,0 +1,297 @@
+package crawler
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
+)
+
+// ImageOptions 图片版面转换为PDF时的参数
+type ImageOptions struct {
+	// DPI 图片没有记录分辨率时使用的默认DPI
+	DPI float64
+	// PaperSize 版面的物理尺寸，如 "A3"、"350x500mm"
+	// 为空时根据图片DPI计算页面尺寸
+	PaperSize string
+}
+
+// DefaultImageOptions 返回默认的图片转换参数
+func DefaultImageOptions() ImageOptions {
+	return ImageOptions{DPI: 150}
+}
+
+// newspaperSizes 常见的报纸幅面，单位为毫米
+var newspaperSizes = map[string][2]float64{
+	"broadsheet": {390, 545}, // 对开
+	"对开":         {390, 545},
+	"tabloid":    {270, 390}, // 四开
+	"四开":         {270, 390},
+}
+
+// defaultPaperSize 图片没有记录分辨率且未指定纸张时，按对开报纸估算图片的实际分辨率
+const defaultPaperSize = "broadsheet"
+
+// ImageOptionsProvider 图片类报纸的获取器可以实现该接口，指定版面图片的转换参数
+type ImageOptionsProvider interface {
+	ImageOptions() ImageOptions
+}
+
+// imageExts 支持的图片扩展名
+var imageExts = []string{".jpg", ".jpeg", ".png", ".gif", ".webp", ".tif", ".tiff"}
+
+// IsImageURL 根据扩展名判断URL或路径是否指向支持的图片格式
+func IsImageURL(u string) bool {
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		u = u[:i]
+	}
+	ext := strings.ToLower(path.Ext(u))
+	for _, e := range imageExts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// imagePage 一张待嵌入PDF的版面图片
+type imagePage struct {
+	data   []byte  // 可直接嵌入PDF的图片数据（JPEG或PNG）
+	format string  // 原始图片格式
+	width  int     // 像素宽度
+	height int     // 像素高度
+	dpi    float64 // 图片记录的分辨率，0表示未知
+}
+
+// decodeImagePage 解析图片数据，保持原始分辨率
+// JPEG和PNG原样嵌入，GIF、WebP、TIFF无损转换为PNG
+func decodeImagePage(data []byte) (*imagePage, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("无法识别的图片格式: %v", err)
+	}
+
+	p := &imagePage{
+		data:   data,
+		format: format,
+		width:  cfg.Width,
+		height: cfg.Height,
+		dpi:    imageDPI(data, format),
+	}
+
+	switch format {
+	case "jpeg", "png":
+		return p, nil
+	case "gif", "webp", "tiff":
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("解码%s图片失败: %v", format, err)
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, fmt.Errorf("转换%s图片失败: %v", format, err)
+		}
+		p.data = buf.Bytes()
+		return p, nil
+	}
+
+	return nil, fmt.Errorf("不支持的图片格式: %s", format)
+}
+
+// layout 计算页面尺寸和图片在页面中的位置，单位为点（1/72英寸）
+func (p *imagePage) layout(opts ImageOptions) (page types.Dim, img types.Rectangle, err error) {
+	if opts.PaperSize == "" {
+		dpi := p.layoutDPI(opts)
+		page = types.Dim{
+			Width:  float64(p.width) * 72 / dpi,
+			Height: float64(p.height) * 72 / dpi,
+		}
+		return page, *types.RectForDim(page.Width, page.Height), nil
+	}
+
+	paper, err := parsePaperSize(opts.PaperSize)
+	if err != nil {
+		return page, img, err
+	}
+
+	// 纸张方向跟随图片方向
+	page = *paper
+	if (p.width > p.height) != (page.Width > page.Height) {
+		page.Width, page.Height = page.Height, page.Width
+	}
+
+	// 等比缩放图片并居中
+	scale := page.Width / float64(p.width)
+	if s := page.Height / float64(p.height); s < scale {
+		scale = s
+	}
+	w, h := float64(p.width)*scale, float64(p.height)*scale
+	x, y := (page.Width-w)/2, (page.Height-h)/2
+	return page, *types.NewRectangle(x, y, x+w, y+h), nil
+}
+
+// layoutDPI 返回未指定纸张时计算页面尺寸使用的分辨率
+func (p *imagePage) layoutDPI(opts ImageOptions) float64 {
+	switch {
+	case p.dpi > 0:
+		return p.dpi
+	case opts.DPI > 0:
+		return opts.DPI
+	}
+	return DefaultImageOptions().DPI
+}
+
+// ValidatePaperSize 检查纸张尺寸的格式，用于提前校验配置
+func ValidatePaperSize(s string) error {
+	_, err := parsePaperSize(s)
+	return err
+}
+
+// parsePaperSize 解析纸张尺寸
+// 支持pdfcpu的纸张名称（如 A3、B4）、报纸幅面（broadsheet、tabloid）或 "宽x高单位" 格式（单位为 mm、cm、in）
+func parsePaperSize(s string) (*types.Dim, error) {
+	if dim, ok := types.PaperSize[strings.ToUpper(s)]; ok {
+		return dim, nil
+	}
+	if mm, ok := newspaperSizes[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return &types.Dim{Width: mm[0] * 72 / 25.4, Height: mm[1] * 72 / 25.4}, nil
+	}
+
+	v := strings.ToLower(strings.TrimSpace(s))
+	units := map[string]float64{"mm": 72 / 25.4, "cm": 72 / 2.54, "in": 72}
+	for unit, factor := range units {
+		if !strings.HasSuffix(v, unit) {
+			continue
+		}
+		parts := strings.Split(strings.TrimSuffix(v, unit), "x")
+		if len(parts) != 2 {
+			break
+		}
+		w, err1 := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		h, err2 := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err1 != nil || err2 != nil || w <= 0 || h <= 0 {
+			break
+		}
+		return &types.Dim{Width: w * factor, Height: h * factor}, nil
+	}
+
+	return nil, fmt.Errorf("无效的纸张尺寸: %s", s)
+}
+
+// writeImagePDF 将多张图片写入同一个PDF，每张图片一页
+func writeImagePDF(w io.Writer, pages []*imagePage, opts ImageOptions) error {
+	conf := model.NewDefaultConfiguration()
+	conf.Cmd = model.IMPORTIMAGES
+
+	ctx, err := pdfcpu.CreateContextWithXRefTable(conf, types.PaperSize["A4"])
+	if err != nil {
+		return err
+	}
+
+	pagesIndRef, err := ctx.Pages()
+	if err != nil {
+		return err
+	}
+
+	pagesDict, err := ctx.DereferenceDict(*pagesIndRef)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range pages {
+		indRef, err := newImagePage(ctx.XRefTable, p, pagesIndRef, opts)
+		if err != nil {
+			return err
+		}
+
+		if err := ctx.SetValid(*indRef); err != nil {
+			return err
+		}
+
+		if err := model.AppendPageTree(indRef, 1, pagesDict); err != nil {
+			return err
+		}
+
+		ctx.PageCount++
+	}
+
+	return api.Write(ctx, w, conf)
+}
+
+// newImagePage 为图片创建一个PDF页面，页面尺寸由 layout 决定
+func newImagePage(xRefTable *model.XRefTable, p *imagePage, parentIndRef *types.IndirectRef, opts ImageOptions) (*types.IndirectRef, error) {
+	pageDim, imgRect, err := p.layout(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	imgIndRef, _, _, err := model.CreateImageResource(xRefTable, bytes.NewReader(p.data), false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resIndRef, err := xRefTable.IndRefForNewObject(types.Dict(
+		map[string]types.Object{
+			"ProcSet": types.NewNameArray("PDF", "ImageB", "ImageC", "ImageI"),
+			"XObject": types.Dict(map[string]types.Object{"Im0": *imgIndRef}),
+		},
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	content := fmt.Sprintf("q %.5f 0 0 %.5f %.5f %.5f cm /Im0 Do Q",
+		imgRect.Width(), imgRect.Height(), imgRect.LL.X, imgRect.LL.Y)
+	sd, err := xRefTable.NewStreamDictForBuf([]byte(content))
+	if err != nil {
+		return nil, err
+	}
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	contentsIndRef, err := xRefTable.IndRefForNewObject(*sd)
+	if err != nil {
+		return nil, err
+	}
+
+	return xRefTable.IndRefForNewObject(types.Dict(
+		map[string]types.Object{
+			"Type":      types.Name("Page"),
+			"Parent":    *parentIndRef,
+			"MediaBox":  types.RectForDim(pageDim.Width, pageDim.Height).Array(),
+			"Resources": *resIndRef,
+			"Contents":  *contentsIndRef,
+		},
+	))
+}
+
+// writeImagePDFFile 将图片写入PDF文件，每张图片一页
+func writeImagePDFFile(pdfPath string, pages []*imagePage, opts ImageOptions) error {
+	out, err := os.Create(pdfPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if err := writeImagePDF(out, pages, opts); err != nil {
+		os.Remove(pdfPath)
+		return err
+	}
+
+	return nil
+}
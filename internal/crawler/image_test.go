@@ -0,0 +1,126 @@
+package crawler
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"math"
+	"testing"
+)
+
+func TestParsePaperSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		w, h    float64 // 毫米
+		wantErr bool
+	}{
+		{in: "A3", w: 297, h: 420},
+		{in: "broadsheet", w: 390, h: 545},
+		{in: "对开", w: 390, h: 545},
+		{in: "Tabloid", w: 270, h: 390},
+		{in: "350x500mm", w: 350, h: 500},
+		{in: "35 x 50cm", w: 350, h: 500},
+		{in: "10x20in", w: 254, h: 508},
+		{in: "350x500", wantErr: true},
+		{in: "0x500mm", wantErr: true},
+		{in: "big", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			dim, err := parsePaperSize(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePaperSize(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			w, h := dim.Width*25.4/72, dim.Height*25.4/72
+			if math.Abs(w-tt.w) > 1 || math.Abs(h-tt.h) > 1 {
+				t.Errorf("parsePaperSize(%q) = %.1fx%.1fmm, want %.0fx%.0fmm", tt.in, w, h, tt.w, tt.h)
+			}
+		})
+	}
+}
+
+func TestImagePageLayout(t *testing.T) {
+	tests := []struct {
+		name       string
+		page       imagePage
+		opts       ImageOptions
+		pageW      float64
+		pageH      float64
+		imgW, imgH float64
+	}{
+		{"记录的DPI", imagePage{width: 3000, height: 4000, dpi: 300}, ImageOptions{DPI: 150}, 720, 960, 720, 960},
+		{"默认DPI", imagePage{width: 1500, height: 2000}, ImageOptions{DPI: 150}, 720, 960, 720, 960},
+		{"未设置DPI", imagePage{width: 1500, height: 2000}, ImageOptions{}, 720, 960, 720, 960},
+		{"纸张等比居中", imagePage{width: 1000, height: 1000}, ImageOptions{PaperSize: "A4"}, 595, 842, 595, 595},
+		{"纸张方向跟随图片", imagePage{width: 2000, height: 1000}, ImageOptions{PaperSize: "A4"}, 842, 595, 842, 421},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, img, err := tt.page.layout(tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(page.Width-tt.pageW) > 1 || math.Abs(page.Height-tt.pageH) > 1 {
+				t.Errorf("page = %.0fx%.0f, want %.0fx%.0f", page.Width, page.Height, tt.pageW, tt.pageH)
+			}
+			if math.Abs(img.Width()-tt.imgW) > 1 || math.Abs(img.Height()-tt.imgH) > 1 {
+				t.Errorf("image = %.0fx%.0f, want %.0fx%.0f", img.Width(), img.Height(), tt.imgW, tt.imgH)
+			}
+		})
+	}
+}
+
+func TestQualityPresetDownscale(t *testing.T) {
+	// 没有记录DPI的图片，宽度按对开报纸 390mm 估算
+	const width, height = 3000, 3750
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		preset string
+		opts   ImageOptions
+		want   int // 缩小后的宽度，0表示不缩小
+	}{
+		{"archive 不缩小", "archive", DefaultImageOptions(), 0},
+		{"screen 未指定纸张", "screen", DefaultImageOptions(), int(math.Round(width * 150 / (width / (390 / 25.4))))},
+		{"screen 指定纸张", "screen", ImageOptions{DPI: 150, PaperSize: "tabloid"}, int(math.Round(width * 150 / (width / (270 / 25.4))))},
+		{"mobile 未指定纸张", "mobile", DefaultImageOptions(), int(math.Round(width * 96 / (width / (390 / 25.4))))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := decodeImagePage(buf.Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+			before, _, _ := p.layout(tt.opts)
+
+			preset, _ := ParseQualityPreset(tt.preset)
+			if err := preset.apply(p, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+
+			want := tt.want
+			if want == 0 {
+				want = width
+			}
+			if math.Abs(float64(p.width-want)) > 1 {
+				t.Errorf("width = %d, want %d", p.width, want)
+			}
+
+			// 缩小后页面的物理尺寸保持不变
+			after, _, _ := p.layout(tt.opts)
+			if math.Abs(after.Width-before.Width) > 1 || math.Abs(after.Height-before.Height) > 1 {
+				t.Errorf("page = %.1fx%.1f, want %.1fx%.1f", after.Width, after.Height, before.Width, before.Height)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package crawler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// 打印排版方式
+const (
+	Layout2Up     = "2up"     // 每张纸2版，横向纸张左右排列
+	Layout4Up     = "4up"     // 每张纸4版，纵向纸张2x2排列
+	LayoutBooklet = "booklet" // 骑马钉小册子，双面打印后对折
+)
+
+// bookletFolioSize 小册子每一帖的纸张数，超出时分为多帖，每帖单独对折
+const bookletFolioSize = 8
+
+// PrintLayout 合并后PDF的打印排版
+type PrintLayout struct {
+	Kind  string // 2up、4up、booklet
+	Paper string // 纸张大小: A4、A3
+}
+
+// ParsePrintLayout 解析 2up、4up:A3、booklet:A4 格式的打印排版，纸张默认为A4
+func ParsePrintLayout(s string) (*PrintLayout, error) {
+	kind, paper, _ := strings.Cut(strings.TrimSpace(s), ":")
+	l := &PrintLayout{Kind: strings.ToLower(kind), Paper: strings.ToUpper(paper)}
+	if l.Paper == "" {
+		l.Paper = "A4"
+	}
+
+	switch l.Kind {
+	case Layout2Up, Layout4Up, LayoutBooklet:
+	default:
+		return nil, fmt.Errorf("未知的打印排版: %s，可选: %s、%s、%s", kind, Layout2Up, Layout4Up, LayoutBooklet)
+	}
+	if l.Paper != "A4" && l.Paper != "A3" {
+		return nil, fmt.Errorf("不支持的纸张大小: %s，可选: A4、A3", paper)
+	}
+	return l, nil
+}
+
+// String 返回打印排版的描述，如 booklet_A4，用于输出文件名
+func (l PrintLayout) String() string {
+	return l.Kind + "_" + l.Paper
+}
+
+// Impose 按打印排版生成新的PDF，页面缩放到指定纸张，返回生成的文件路径
+// 输出文件与原文件在同一目录，如 rmrb_20251110_booklet_A4.pdf
+func Impose(path string, l PrintLayout) (string, error) {
+	out := strings.TrimSuffix(path, ".pdf") + "_" + l.String() + ".pdf"
+	conf := model.NewDefaultConfiguration()
+
+	switch l.Kind {
+	case Layout2Up:
+		// 报纸版面为纵向，2版并排时使用横向纸张
+		nup, err := api.PDFNUpConfig(2, fmt.Sprintf("formsize:%sL, border:off", l.Paper), conf)
+		if err != nil {
+			return "", err
+		}
+		return out, api.NUpFile([]string{path}, out, nil, nup, conf)
+
+	case Layout4Up:
+		nup, err := api.PDFNUpConfig(4, fmt.Sprintf("formsize:%s, border:off", l.Paper), conf)
+		if err != nil {
+			return "", err
+		}
+		return out, api.NUpFile([]string{path}, out, nil, nup, conf)
+
+	case LayoutBooklet:
+		// 横向纸张左右各一版，沿短边翻转双面打印后从中间对折
+		// 页数较多时分帖，pdfcpu按帖计算页面顺序并补足空白页
+		desc := fmt.Sprintf("formsize:%sL, binding:short, multifolio:on, foliosize:%d", l.Paper, bookletFolioSize)
+		nup, err := api.PDFBookletConfig(2, desc, conf)
+		if err != nil {
+			return "", err
+		}
+		return out, api.BookletFile([]string{path}, out, nil, nup, conf)
+	}
+
+	return "", fmt.Errorf("未知的打印排版: %s", l.Kind)
+}
@@ -0,0 +1,31 @@
+package crawler
+
+import (
+	"fmt"
+	"time"
+)
+
+// FindLatest 从 from 开始逐日向前查找最近已发布的一期，最多回溯 maxDays 天
+// 非出版日直接跳过，ignoreSchedule 为 true 时非出版日也会检查
+func (p *Paper) FindLatest(from time.Time, maxDays int, ignoreSchedule bool) (*Crawler, error) {
+	var lastErr error
+	for i := 0; i <= maxDays; i++ {
+		date := from.AddDate(0, 0, -i)
+		if !ignoreSchedule && !p.PublishesOn(date) {
+			continue
+		}
+
+		c := p.NewCrawler(date)
+		err := c.Available()
+		if err == nil {
+			return c, nil
+		}
+		fmt.Printf("[%s] %s 未发布 (%v)\n", p.Code, c.GetDateString(), err)
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		return nil, fmt.Errorf("最近 %d 天内没有出版日", maxDays)
+	}
+	return nil, fmt.Errorf("最近 %d 天内没有找到已发布的一期: %v", maxDays, lastErr)
+}
@@ -0,0 +1,160 @@
+package crawler
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// Metadata 合并后PDF的文档信息
+type Metadata struct {
+	Title    string   // 如 人民日报 2025-11-10
+	Author   string   // 出版单位
+	Subject  string   // 出版日期
+	Keywords []string // 版面名称
+	Sources  []string // 各版面资源的地址
+
+	CoverPages int // 封面和目录的页数，只保留头版时跳过
+}
+
+// metadata 根据报纸注册信息和已下载的版面生成文档信息
+func (c *Crawler) metadata() Metadata {
+	date := c.GetDateString()
+	m := Metadata{
+		Title:   PaperName(c.PaperType) + " " + date,
+		Subject: date,
+	}
+	if c.Selection != nil {
+		m.Title += "（" + c.Selection.String() + "）"
+	}
+	if p, ok := LookupPaper(c.PaperType); ok {
+		m.Author = p.Publisher
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range c.pages {
+		if p.section != "" && !seen[p.section] {
+			seen[p.section] = true
+			m.Keywords = append(m.Keywords, p.section)
+		}
+		if strings.HasPrefix(p.source, "http") {
+			m.Sources = append(m.Sources, p.source)
+		}
+	}
+	return m
+}
+
+// annotate 为合并后的PDF写入文档信息、书签和页码标签，添加水印和页眉，并插入封面和目录
+func (c *Crawler) annotate(path string) error {
+	ctx, err := api.ReadContextFile(path)
+	if err != nil {
+		return err
+	}
+
+	m := c.metadata()
+
+	// 每个版面应对应一页，页数不一致时无法确定书签、页码标签、页眉和目录的位置
+	perPage := ctx.PageCount == len(c.pages)
+	if !perPage {
+		fmt.Printf("警告: 合并后共 %d 页，与 %d 个版面不一致，不添加书签、页码标签、页眉和目录\n", ctx.PageCount, len(c.pages))
+	}
+
+	// 水印和页眉按版面序号添加，需在插入封面之前
+	if c.Stamp != nil {
+		if err := c.applyStamp(ctx, perPage); err != nil {
+			return err
+		}
+	}
+
+	if perPage {
+		var labels []string
+		if c.Cover {
+			fontName, err := ResolveFont(c.CoverFont)
+			if err != nil {
+				return err
+			}
+			n, err := c.addCover(ctx, fontName)
+			if err != nil {
+				return fmt.Errorf("生成封面失败: %v", err)
+			}
+			labels = coverLabels(n)
+			m.CoverPages = n
+		}
+
+		if err := pdfcpu.AddBookmarks(ctx, c.outline(len(labels)), true); err != nil {
+			return fmt.Errorf("添加书签失败: %v", err)
+		}
+		if err := setPageLabels(ctx, append(labels, c.pageLabels()...)); err != nil {
+			return fmt.Errorf("设置页码标签失败: %v", err)
+		}
+	}
+
+	if err := setMetadata(ctx, m); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := api.WriteContextFile(ctx, tmp); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// setMetadata 设置文档信息字典
+// 创建日期由pdfcpu在写入时设置为当前时间
+func setMetadata(ctx *model.Context, m Metadata) error {
+	d := types.NewDict()
+	d.Insert("Title", pdfText(m.Title))
+	d.Insert("Subject", pdfText(m.Subject))
+	d.Insert("Creator", types.StringLiteral("papers"))
+	if m.Author != "" {
+		d.Insert("Author", pdfText(m.Author))
+	}
+	if len(m.Keywords) > 0 {
+		d.Insert("Keywords", pdfText(strings.Join(m.Keywords, ", ")))
+	}
+	// 来源地址写入自定义字段，每行一个
+	if len(m.Sources) > 0 {
+		d.Insert("Sources", pdfText(strings.Join(m.Sources, "\n")))
+	}
+	if m.CoverPages > 0 {
+		d.Insert("CoverPages", types.Integer(m.CoverPages))
+	}
+
+	ir, err := ctx.IndRefForNewObject(d)
+	if err != nil {
+		return err
+	}
+	ctx.Info = ir
+	return nil
+}
+
+// pdfText 将字符串编码为PDF文本字符串，使用UTF-16以支持中文
+func pdfText(s string) types.HexLiteral {
+	return types.NewHexLiteral([]byte(types.EncodeUTF16String(s)))
+}
+
+// coverPages 返回合并时插入的封面和目录的页数
+// 没有记录页数的文件按页码标签中的封面、目录页计算
+func coverPages(ctx *model.Context) int {
+	if ctx.Info != nil {
+		if d, err := ctx.DereferenceDict(*ctx.Info); err == nil && d != nil {
+			if n := d.IntEntry("CoverPages"); n != nil && *n > 0 && *n < ctx.PageCount {
+				return *n
+			}
+		}
+	}
+
+	labels := readPageLabels(ctx)
+	n := 0
+	for n < len(labels)-1 && (labels[n] == "封面" || labels[n] == "目录") {
+		n++
+	}
+	return n
+}
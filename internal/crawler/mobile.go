@@ -0,0 +1,178 @@
+package crawler
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// maxMobileTiles 单个方向最多切分的块数
+const maxMobileTiles = 6
+
+// tileOverlap 相邻块之间重叠的比例（占版面宽高），避免切断的文字在两块中都看不全
+const tileOverlap = 0.02
+
+// MobileLayout 手机阅读版的切分网格
+type MobileLayout struct {
+	Cols int // 列数
+	Rows int // 行数
+}
+
+// ParseMobileLayout 解析手机阅读版的切分方式
+// grid 为 2x2 网格，strips 为 3 列竖条，也可以直接指定 列数x行数，如 4x1
+func ParseMobileLayout(s string) (*MobileLayout, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "", "grid":
+		return &MobileLayout{Cols: 2, Rows: 2}, nil
+	case "strips":
+		return &MobileLayout{Cols: 3, Rows: 1}, nil
+	}
+
+	cols, rows, ok := strings.Cut(s, "x")
+	c, err1 := strconv.Atoi(cols)
+	r, err2 := strconv.Atoi(rows)
+	if !ok || err1 != nil || err2 != nil {
+		return nil, fmt.Errorf("无效的切分方式: %s，可选: grid、strips 或 列数x行数 (例: 2x2、3x1)", s)
+	}
+	if c < 1 || r < 1 || c > maxMobileTiles || r > maxMobileTiles || c*r < 2 {
+		return nil, fmt.Errorf("无效的切分方式: %s，行列数应在 1-%d 之间且至少切为2块", s, maxMobileTiles)
+	}
+	return &MobileLayout{Cols: c, Rows: r}, nil
+}
+
+// String 返回切分方式的描述，如 2x2
+func (l MobileLayout) String() string {
+	return fmt.Sprintf("%dx%d", l.Cols, l.Rows)
+}
+
+// tiles 按中文报纸的阅读顺序返回一个版面的各块：从右到左逐列，每列从上到下
+// 坐标为相对版面的比例，原点在左下角
+func (l MobileLayout) tiles() []types.Rectangle {
+	w, h := 1/float64(l.Cols), 1/float64(l.Rows)
+	tiles := make([]types.Rectangle, 0, l.Cols*l.Rows)
+	for col := l.Cols - 1; col >= 0; col-- {
+		for row := 0; row < l.Rows; row++ {
+			llx := max(float64(col)*w-tileOverlap, 0)
+			urx := min(float64(col+1)*w+tileOverlap, 1)
+			ury := min(1-float64(row)*h+tileOverlap, 1)
+			lly := max(1-float64(row+1)*h-tileOverlap, 0)
+			tiles = append(tiles, *types.NewRectangle(llx, lly, urx, ury))
+		}
+	}
+	return tiles
+}
+
+// Mobile 将合并后的PDF每个版面切分为多块，生成适合手机和电子书阅读器的PDF，返回生成的文件路径
+// 每块是引用原版面内容的新页面，通过 MediaBox 和 CropBox 只显示版面的一部分，不会重复存储图片
+// 输出文件与原文件在同一目录，如 rmrb_20251110_mobile.pdf
+func Mobile(path string, l MobileLayout) (string, error) {
+	out := strings.TrimSuffix(path, ".pdf") + "_mobile.pdf"
+
+	src, err := api.ReadContextFile(path)
+	if err != nil {
+		return "", fmt.Errorf("读取 %s 失败: %v", path, err)
+	}
+	bookmarks, err := pdfcpu.Bookmarks(src)
+	if err != nil {
+		return "", fmt.Errorf("读取书签失败: %v", err)
+	}
+	labels := readPageLabels(src)
+
+	// 每个版面按块数重复，各块使用独立的页面字典
+	// 链接的目标是原文件中的页面，复制前移除，目录改用书签跳转
+	tiles := l.tiles()
+	pageNrs := make([]int, 0, src.PageCount*len(tiles))
+	for i := 1; i <= src.PageCount; i++ {
+		d, _, _, err := src.PageDict(i, false)
+		if err != nil {
+			return "", err
+		}
+		d.Delete("Annots")
+		for range tiles {
+			pageNrs = append(pageNrs, i)
+		}
+	}
+	ctx, err := pdfcpu.ExtractPages(src, pageNrs, false)
+	if err != nil {
+		return "", fmt.Errorf("复制版面失败: %v", err)
+	}
+	ctx.PageCount = len(pageNrs)
+
+	var tileLabels []string
+	for i := 1; i <= ctx.PageCount; i++ {
+		tile := tiles[(i-1)%len(tiles)]
+		if err := cropPage(ctx, i, tile); err != nil {
+			return "", fmt.Errorf("切分第 %d 页失败: %v", (i-1)/len(tiles)+1, err)
+		}
+		tileLabels = append(tileLabels, fmt.Sprintf("%s-%d", labels[(i-1)/len(tiles)], (i-1)%len(tiles)+1))
+	}
+
+	m := Metadata{Title: src.Title, Author: src.Author, Subject: src.Subject}
+	if src.Keywords != "" {
+		m.Keywords = strings.Split(src.Keywords, ", ")
+	}
+	if err := setMetadata(ctx, m); err != nil {
+		return "", err
+	}
+	if len(bookmarks) > 0 {
+		if err := pdfcpu.AddBookmarks(ctx, scaleBookmarks(bookmarks, len(tiles)), true); err != nil {
+			return "", fmt.Errorf("添加书签失败: %v", err)
+		}
+	}
+	if err := setPageLabels(ctx, tileLabels); err != nil {
+		return "", fmt.Errorf("设置页码标签失败: %v", err)
+	}
+
+	if err := api.WriteContextFile(ctx, out+".new"); err != nil {
+		os.Remove(out + ".new")
+		return "", err
+	}
+	return out, os.Rename(out+".new", out)
+}
+
+// cropPage 将页面的可见区域设置为原版面中 tile 比例对应的部分
+func cropPage(ctx *model.Context, pageNr int, tile types.Rectangle) error {
+	d, _, inh, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+
+	// 原版面已有裁剪区域时在其基础上切分
+	box := inh.MediaBox
+	if a := d.ArrayEntry("CropBox"); a != nil {
+		if box, err = ctx.RectForArray(a); err != nil {
+			return err
+		}
+	} else if inh.CropBox != nil {
+		box = inh.CropBox
+	}
+
+	w, h := box.Width(), box.Height()
+	r := types.NewRectangle(
+		box.LL.X+tile.LL.X*w, box.LL.Y+tile.LL.Y*h,
+		box.LL.X+tile.UR.X*w, box.LL.Y+tile.UR.Y*h,
+	)
+	d.Update("MediaBox", r.Array())
+	d.Update("CropBox", r.Array())
+	return nil
+}
+
+// scaleBookmarks 复制书签，第 n 页的书签指向该版面的第一块
+func scaleBookmarks(bms []pdfcpu.Bookmark, tiles int) []pdfcpu.Bookmark {
+	scaled := make([]pdfcpu.Bookmark, 0, len(bms))
+	for _, bm := range bms {
+		scaled = append(scaled, pdfcpu.Bookmark{
+			Title:    strings.TrimSpace(bm.Title),
+			PageFrom: (bm.PageFrom-1)*tiles + 1,
+			Kids:     scaleBookmarks(bm.Kids, tiles),
+		})
+	}
+	return scaled
+}
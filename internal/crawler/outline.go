@@ -0,0 +1,155 @@
+package crawler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// outline 生成每个版面一个书签的目录，offset 为版面之前插入的封面和目录页数
+// 版次带字母前缀且不止一叠时（如 A01、B01），按叠分组嵌套
+func (c *Crawler) outline(offset int) []pdfcpu.Bookmark {
+	var bookmarks []pdfcpu.Bookmark
+	var stacks []string
+	byStack := make(map[string]*pdfcpu.Bookmark)
+
+	for i, p := range c.pages {
+		bm := pdfcpu.Bookmark{Title: p.title(), PageFrom: offset + i + 1}
+		bookmarks = append(bookmarks, bm)
+
+		stack := p.stack()
+		if stack == "" {
+			continue
+		}
+		if _, ok := byStack[stack]; !ok {
+			stacks = append(stacks, stack)
+			byStack[stack] = &pdfcpu.Bookmark{Title: stack + "叠", PageFrom: offset + i + 1}
+		}
+		byStack[stack].Kids = append(byStack[stack].Kids, bm)
+	}
+
+	// 所有版面都属于某一叠且不止一叠时才嵌套
+	nested := 0
+	for _, s := range stacks {
+		nested += len(byStack[s].Kids)
+	}
+	if len(stacks) >= 2 && nested == len(c.pages) {
+		bookmarks = make([]pdfcpu.Bookmark, 0, len(stacks))
+		for _, s := range stacks {
+			bookmarks = append(bookmarks, *byStack[s])
+		}
+	}
+
+	if offset > 0 {
+		bookmarks = append([]pdfcpu.Bookmark{{Title: "目录", PageFrom: 1}}, bookmarks...)
+	}
+	return bookmarks
+}
+
+// title 返回版面的书签标题，如 第05版：评论；没有版次时使用版面序号
+func (p pageFile) title() string {
+	if p.section == "" {
+		return fmt.Sprintf("第%s版", p.displayLabel())
+	}
+	return fmt.Sprintf("第%s版：%s", p.displayLabel(), p.section)
+}
+
+// displayLabel 返回版次，没有版次时使用两位数的版面序号
+func (p pageFile) displayLabel() string {
+	if p.label == "" {
+		return fmt.Sprintf("%02d", p.page)
+	}
+	return p.label
+}
+
+// stack 返回版次的字母前缀，如 A01 属于 A 叠；纯数字版次返回空字符串
+func (p pageFile) stack() string {
+	return strings.TrimRight(p.label, "0123456789")
+}
+
+// readOutline 读取文档的书签
+// pdfcpu.Bookmarks 会跳过只有一个书签的顶层，头版等只有一个书签的报纸读不到书签
+func readOutline(ctx *model.Context) ([]pdfcpu.Bookmark, error) {
+	if err := ctx.LocateNameTree("Dests", false); err != nil {
+		return nil, err
+	}
+	if ctx.Outlines == nil {
+		return nil, nil
+	}
+	first := ctx.Outlines.IndirectRefEntry("First")
+	if first == nil {
+		return nil, nil
+	}
+	return pdfcpu.BookmarksForOutlineItem(ctx, first, nil)
+}
+
+// addOutline 用书签替换文档原有的书签，每个书签直接指向所在的页面
+// pdfcpu.AddBookmarks 以书签标题作为命名目标，标题相同的书签会跳转到同一页，
+// 合集中不同报纸的“第01版”等同名书签需要各自指向对应的页面
+func addOutline(ctx *model.Context, bms []pdfcpu.Bookmark) error {
+	root, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	outlines := types.Dict{"Type": types.Name("Outlines")}
+	ir, err := ctx.IndRefForNewObject(outlines)
+	if err != nil {
+		return err
+	}
+	first, last, count, err := outlineItems(ctx, bms, *ir)
+	if err != nil {
+		return err
+	}
+	if first != nil {
+		outlines.Insert("First", *first)
+		outlines.Insert("Last", *last)
+		outlines.Insert("Count", types.Integer(count))
+	}
+	root.Update("Outlines", *ir)
+	return nil
+}
+
+// outlineItems 生成同一层级的书签，返回第一个和最后一个书签，以及展开后可见的书签数
+func outlineItems(ctx *model.Context, bms []pdfcpu.Bookmark, parent types.IndirectRef) (first, last *types.IndirectRef, count int, err error) {
+	var prev types.Dict
+	for _, bm := range bms {
+		_, page, _, err := ctx.PageDict(bm.PageFrom, false)
+		if err != nil || page == nil {
+			return nil, nil, 0, fmt.Errorf("书签 %s 的页码 %d 无效", bm.Title, bm.PageFrom)
+		}
+
+		d := types.Dict{
+			"Title":  pdfText(bm.Title),
+			"Parent": parent,
+			"Dest":   types.Array{*page, types.Name("Fit")},
+		}
+		ir, err := ctx.IndRefForNewObject(d)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		kidsFirst, kidsLast, kids, err := outlineItems(ctx, bm.Kids, *ir)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if kidsFirst != nil {
+			d.Insert("First", *kidsFirst)
+			d.Insert("Last", *kidsLast)
+			d.Insert("Count", types.Integer(kids))
+		}
+
+		if prev != nil {
+			prev.Insert("Next", *ir)
+			d.Insert("Prev", *last)
+		} else {
+			first = ir
+		}
+		prev, last = d, ir
+		count += 1 + kids
+	}
+	return first, last, count, nil
+}
@@ -0,0 +1,85 @@
+package crawler
+
+import (
+	"strconv"
+	"unicode"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// setPageLabels 设置页码标签，使阅读器显示的页码与报纸印刷的版次一致
+// 版次可能带前导零或字母前缀（01、A01），无法用数字样式表示，因此每页单独设置前缀
+func setPageLabels(ctx *model.Context, labels []string) error {
+	root, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	nums := make(types.Array, 0, 2*len(labels))
+	for i, label := range labels {
+		d := types.NewDict()
+		d.Insert("P", labelText(label))
+		nums = append(nums, types.Integer(i), d)
+	}
+
+	pageLabels := types.NewDict()
+	pageLabels.Insert("Nums", nums)
+	root.Update("PageLabels", pageLabels)
+	return nil
+}
+
+// pageLabels 返回每个版面的页码标签，没有版次时使用版面序号
+func (c *Crawler) pageLabels() []string {
+	labels := make([]string, len(c.pages))
+	for i, p := range c.pages {
+		labels[i] = p.displayLabel()
+	}
+	return labels
+}
+
+// labelText 将页码标签编码为PDF文本字符串，包含中文时使用UTF-16
+func labelText(label string) types.Object {
+	for _, r := range label {
+		if r > unicode.MaxASCII {
+			return pdfText(label)
+		}
+	}
+	return types.StringLiteral(label)
+}
+
+// readPageLabels 读取每页的页码标签，只识别逐页设置前缀的标签，其余页面使用页码
+func readPageLabels(ctx *model.Context) []string {
+	labels := make([]string, ctx.PageCount)
+	for i := range labels {
+		labels[i] = strconv.Itoa(i + 1)
+	}
+
+	root, err := ctx.Catalog()
+	if err != nil {
+		return labels
+	}
+	d, err := ctx.DereferenceDict(root["PageLabels"])
+	if err != nil || d == nil {
+		return labels
+	}
+	nums, err := ctx.DereferenceArray(d["Nums"])
+	if err != nil {
+		return labels
+	}
+
+	for i := 0; i+1 < len(nums); i += 2 {
+		idx, ok := nums[i].(types.Integer)
+		if !ok || int(idx) < 0 || int(idx) >= len(labels) {
+			continue
+		}
+		ld, err := ctx.DereferenceDict(nums[i+1])
+		if err != nil || ld == nil || ld["S"] != nil {
+			continue
+		}
+		if p, err := types.StringOrHexLiteral(ld["P"]); err == nil && p != nil {
+			labels[idx] = *p
+		}
+	}
+	return labels
+}
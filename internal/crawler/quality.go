@@ -0,0 +1,131 @@
+package crawler
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"math"
+	"sort"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+// QualityPreset 图片版面嵌入PDF前的压缩预设
+type QualityPreset struct {
+	Name        string
+	DPI         float64 // 目标分辨率，超过该分辨率的图片会被缩小，0表示保持原始分辨率
+	JPEGQuality int     // JPEG重新编码的质量(1-100)，0表示不重新编码
+	Gray        bool    // 是否转换为灰度图，适合打印
+}
+
+// qualityPresets 内置的压缩预设
+var qualityPresets = map[string]QualityPreset{
+	// archive 保留网站提供的原图
+	"archive": {Name: "archive"},
+	// screen 适合电脑屏幕阅读
+	"screen": {Name: "screen", DPI: 150, JPEGQuality: 80},
+	// mobile 适合手机阅读，体积最小
+	"mobile": {Name: "mobile", DPI: 96, JPEGQuality: 65},
+}
+
+// QualityPresetNames 返回所有预设名称
+func QualityPresetNames() []string {
+	names := make([]string, 0, len(qualityPresets))
+	for name := range qualityPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseQualityPreset 根据名称获取压缩预设，空字符串表示 archive
+func ParseQualityPreset(name string) (QualityPreset, error) {
+	if name == "" {
+		return qualityPresets["archive"], nil
+	}
+	preset, ok := qualityPresets[strings.ToLower(name)]
+	if !ok {
+		return QualityPreset{}, fmt.Errorf("未知的质量预设: %s (可选: %s)", name, strings.Join(QualityPresetNames(), ", "))
+	}
+	return preset, nil
+}
+
+// isLossless 预设是否保持图片原样
+func (q QualityPreset) isLossless() bool {
+	return q.DPI == 0 && q.JPEGQuality == 0 && !q.Gray
+}
+
+// apply 按预设缩小、转灰度并重新编码图片
+// 缩小后页面的物理尺寸保持不变
+func (q QualityPreset) apply(p *imagePage, opts ImageOptions) error {
+	if q.isLossless() {
+		return nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(p.data))
+	if err != nil {
+		return fmt.Errorf("解码图片失败: %v", err)
+	}
+
+	changed := false
+
+	// 按目标分辨率缩小
+	dpi, err := p.effectiveDPI(opts)
+	if err != nil {
+		return err
+	}
+	if q.DPI > 0 && dpi > q.DPI {
+		// 未指定纸张时页面尺寸由 layoutDPI 决定，按相同比例调整以保持页面尺寸
+		layoutDPI := p.layoutDPI(opts)
+		scale := q.DPI / dpi
+		w := int(math.Round(float64(p.width) * scale))
+		h := int(math.Round(float64(p.height) * scale))
+		dst := image.NewRGBA(image.Rect(0, 0, w, h))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
+		img = dst
+		p.width, p.height = w, h
+		p.dpi = layoutDPI * scale
+		changed = true
+	}
+
+	if q.Gray {
+		gray := image.NewGray(img.Bounds())
+		draw.Draw(gray, gray.Bounds(), img, img.Bounds().Min, draw.Src)
+		img = gray
+		changed = true
+	}
+
+	quality := q.JPEGQuality
+	if quality == 0 {
+		quality = 90
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return fmt.Errorf("编码JPEG失败: %v", err)
+	}
+
+	// 仅重新编码时，结果更大则保留原图
+	if !changed && buf.Len() >= len(p.data) {
+		return nil
+	}
+
+	p.data = buf.Bytes()
+	p.format = "jpeg"
+	return nil
+}
+
+// effectiveDPI 计算图片在页面上的实际分辨率
+// 图片没有记录分辨率且未指定纸张时，默认DPI只决定页面尺寸，按对开报纸估算实际分辨率
+func (p *imagePage) effectiveDPI(opts ImageOptions) (float64, error) {
+	if p.dpi == 0 && opts.PaperSize == "" {
+		opts.PaperSize = defaultPaperSize
+	}
+	_, rect, err := p.layout(opts)
+	if err != nil {
+		return 0, err
+	}
+	return float64(p.width) / (rect.Width() / 72), nil
+}
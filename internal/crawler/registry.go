@@ -0,0 +1,117 @@
+package crawler
+
+import (
+	"fmt"
+	"time"
+
+	"papers/internal/config"
+)
+
+// Paper 已注册报纸的描述
+type Paper struct {
+	Code   string // 报纸代码，如 rmrb
+	Name   string // 中文名称
+	Family string // 所属系列，如 people、anhui
+
+	// Publisher 出版单位，写入合并后PDF的作者信息
+	Publisher string
+
+	// Schedule 出版规则，为 nil 表示每天出版
+	Schedule *PublicationSchedule
+
+	// NewFetcher 创建指定日期的获取器
+	NewFetcher func(date time.Time) PaperFetcher
+
+	registered *PublicationSchedule // 注册时的出版规则，配置文件的覆盖在其基础上合并
+}
+
+// NewCrawler 创建该报纸指定日期的爬虫实例
+func (p *Paper) NewCrawler(date time.Time) *Crawler {
+	return NewCrawlerForDate(p.Code, p.NewFetcher(date), date)
+}
+
+// PublishesOn 判断报纸在指定日期是否出版
+func (p *Paper) PublishesOn(date time.Time) bool {
+	return p.Schedule.PublishesOn(date)
+}
+
+// registry 按注册顺序保存所有报纸
+var registry []*Paper
+
+// Register 注册报纸，注册顺序即默认的下载顺序
+// 各报纸系列的包在 init 中调用
+func Register(p *Paper) {
+	if _, ok := LookupPaper(p.Code); ok {
+		panic(fmt.Sprintf("crawler: 报纸 %s 重复注册", p.Code))
+	}
+	p.registered = p.Schedule
+	registry = append(registry, p)
+}
+
+// ApplyConfig 用配置文件中的设置覆盖已注册报纸的出版规则，并将字体安装目录设置到状态目录下
+// 每次都在注册的规则基础上合并，可以在重新读取配置后再次调用
+func ApplyConfig(cfg *config.Config) error {
+	fontDir = cfg.StatePath("fonts")
+
+	for code := range cfg.Papers {
+		if _, ok := LookupPaper(code); !ok {
+			return fmt.Errorf("配置文件中的报纸 %s 未注册", code)
+		}
+	}
+
+	for _, p := range registry {
+		p.Schedule = p.registered
+		override := cfg.Paper(p.Code).Schedule
+		if override == nil {
+			continue
+		}
+		s, err := p.registered.merge(*override)
+		if err != nil {
+			return fmt.Errorf("配置文件中 %s 的出版规则无效: %v", p.Code, err)
+		}
+		p.Schedule = s
+	}
+	return nil
+}
+
+// LookupPaper 根据报纸代码查找已注册的报纸
+func LookupPaper(code string) (*Paper, bool) {
+	for _, p := range registry {
+		if p.Code == code {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// Papers 返回所有已注册的报纸
+func Papers() []*Paper {
+	return append([]*Paper(nil), registry...)
+}
+
+// FamilyPapers 返回某个系列的所有报纸
+func FamilyPapers(family string) []*Paper {
+	var papers []*Paper
+	for _, p := range registry {
+		if p.Family == family {
+			papers = append(papers, p)
+		}
+	}
+	return papers
+}
+
+// PaperScheduled 判断报纸在指定日期是否出版，未注册的报纸视为每天出版
+func PaperScheduled(code string, date time.Time) bool {
+	if p, ok := LookupPaper(code); ok {
+		return p.PublishesOn(date)
+	}
+	return true
+}
+
+// PaperName 返回报纸的中文名称，未注册时返回报纸代码
+func PaperName(code string) string {
+	if p, ok := LookupPaper(code); ok {
+		return p.Name
+	}
+	return code
+}
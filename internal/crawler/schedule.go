@@ -0,0 +1,133 @@
+package crawler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"papers/internal/config"
+)
+
+// PublicationSchedule 报纸的出版规则，各条件同时满足时才出版
+type PublicationSchedule struct {
+	// Weekdays 出版的星期，为空表示每天
+	Weekdays []time.Weekday
+	// EveryNDays 每隔N天出版一次，0表示不限制
+	EveryNDays int
+	// Anchor EveryNDays 的起算日期，应为某个已知的出版日
+	Anchor time.Time
+	// Holidays 休刊日期，格式: 2006-01-02
+	Holidays []string
+}
+
+// Weekly 返回按星期出版的规则
+func Weekly(days ...time.Weekday) *PublicationSchedule {
+	return &PublicationSchedule{Weekdays: days}
+}
+
+// merge 在出版规则基础上应用配置文件中的规则，返回新的规则
+// 配置了星期或 every_n_days 时替换原有的对应规则，休刊日追加到原有的休刊日
+func (s *PublicationSchedule) merge(o config.PaperSchedule) (*PublicationSchedule, error) {
+	merged := &PublicationSchedule{}
+	if s != nil {
+		*merged = *s
+		merged.Holidays = append([]string(nil), s.Holidays...)
+	}
+
+	if len(o.Weekdays) > 0 {
+		merged.Weekdays = nil
+		for _, w := range o.Weekdays {
+			d, err := config.ParseWeekday(w)
+			if err != nil {
+				return nil, err
+			}
+			merged.Weekdays = append(merged.Weekdays, d)
+		}
+	}
+
+	if o.EveryNDays < 0 {
+		return nil, fmt.Errorf("every_n_days 不能为负数")
+	}
+	if o.EveryNDays > 0 {
+		anchor, err := time.Parse("2006-01-02", o.Anchor)
+		if err != nil {
+			return nil, fmt.Errorf("every_n_days 需要指定起算日期 anchor，格式: 2006-01-02")
+		}
+		merged.EveryNDays = o.EveryNDays
+		merged.Anchor = anchor
+	}
+
+	for _, h := range o.Holidays {
+		if _, err := time.Parse("2006-01-02", h); err != nil {
+			return nil, fmt.Errorf("无效的休刊日期: %s，格式应为 2006-01-02", h)
+		}
+		merged.Holidays = append(merged.Holidays, h)
+	}
+
+	return merged, nil
+}
+
+// PublishesOn 判断指定日期是否出版
+func (s *PublicationSchedule) PublishesOn(date time.Time) bool {
+	if s == nil {
+		return true
+	}
+
+	day := date.Format("2006-01-02")
+	for _, h := range s.Holidays {
+		if h == day {
+			return false
+		}
+	}
+
+	if len(s.Weekdays) > 0 {
+		found := false
+		for _, d := range s.Weekdays {
+			if d == date.Weekday() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if s.EveryNDays > 0 {
+		anchor := time.Date(s.Anchor.Year(), s.Anchor.Month(), s.Anchor.Day(), 0, 0, 0, 0, time.UTC)
+		d := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		days := int(d.Sub(anchor).Hours() / 24)
+		if days%s.EveryNDays != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String 返回出版规则的描述
+func (s *PublicationSchedule) String() string {
+	if s == nil {
+		return "每天"
+	}
+
+	var parts []string
+	if len(s.Weekdays) > 0 {
+		names := []string{"日", "一", "二", "三", "四", "五", "六"}
+		days := make([]string, len(s.Weekdays))
+		for i, d := range s.Weekdays {
+			days[i] = names[d]
+		}
+		parts = append(parts, "每周"+strings.Join(days, "、"))
+	}
+	if s.EveryNDays > 0 {
+		parts = append(parts, fmt.Sprintf("每%d天（自%s起）", s.EveryNDays, s.Anchor.Format("2006-01-02")))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "每天")
+	}
+	if len(s.Holidays) > 0 {
+		parts = append(parts, fmt.Sprintf("%d个休刊日", len(s.Holidays)))
+	}
+	return strings.Join(parts, "，")
+}
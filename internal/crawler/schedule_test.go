@@ -0,0 +1,110 @@
+package crawler
+
+import (
+	"testing"
+	"time"
+
+	"papers/internal/config"
+)
+
+// day 返回2025年11月的日期，2025-11-10 为星期一
+func day(d int) time.Time {
+	return time.Date(2025, 11, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPublishesOn(t *testing.T) {
+	everyOther := &PublicationSchedule{EveryNDays: 2, Anchor: day(10)}
+
+	tests := []struct {
+		name     string
+		schedule *PublicationSchedule
+		date     time.Time
+		want     bool
+	}{
+		{"nil 每天出版", nil, day(10), true},
+		{"空规则每天出版", &PublicationSchedule{}, day(11), true},
+		{"出版的星期", Weekly(time.Tuesday, time.Friday), day(11), true},
+		{"非出版的星期", Weekly(time.Tuesday, time.Friday), day(12), false},
+		{"起算日期", everyOther, day(10), true},
+		{"隔日出版", everyOther, day(12), true},
+		{"隔日休刊", everyOther, day(11), false},
+		{"起算日期之前", everyOther, day(8), true},
+		{"起算日期之前休刊", everyOther, day(7), false},
+		{"起算日期带时区", &PublicationSchedule{EveryNDays: 7, Anchor: time.Date(2025, 11, 10, 23, 0, 0, 0, time.FixedZone("CST", 8*3600))}, day(17), true},
+		{"休刊日", &PublicationSchedule{Holidays: []string{"2025-11-10"}}, day(10), false},
+		{"休刊日以外", &PublicationSchedule{Holidays: []string{"2025-11-10"}}, day(11), true},
+		{"条件同时满足", &PublicationSchedule{Weekdays: []time.Weekday{time.Monday}, EveryNDays: 14, Anchor: day(3)}, day(17), true},
+		{"星期满足但不在周期内", &PublicationSchedule{Weekdays: []time.Weekday{time.Monday}, EveryNDays: 14, Anchor: day(3)}, day(10), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.PublishesOn(tt.date); got != tt.want {
+				t.Errorf("PublishesOn(%s) = %v, want %v", tt.date.Format("2006-01-02"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleMerge(t *testing.T) {
+	base := &PublicationSchedule{Weekdays: []time.Weekday{time.Friday}, Holidays: []string{"2025-01-01"}}
+
+	tests := []struct {
+		name     string
+		base     *PublicationSchedule
+		override config.PaperSchedule
+		publish  []time.Time
+		skip     []time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "追加休刊日",
+			base:     base,
+			override: config.PaperSchedule{Holidays: []string{"2025-11-14"}},
+			publish:  []time.Time{day(21)},
+			skip:     []time.Time{day(14), day(13)},
+		},
+		{
+			name:     "替换星期",
+			base:     base,
+			override: config.PaperSchedule{Weekdays: []string{"mon", "thu"}},
+			publish:  []time.Time{day(10), day(13)},
+			skip:     []time.Time{day(14)},
+		},
+		{
+			name:     "没有内置规则",
+			base:     nil,
+			override: config.PaperSchedule{EveryNDays: 3, Anchor: "2025-11-10"},
+			publish:  []time.Time{day(10), day(13)},
+			skip:     []time.Time{day(11), day(12)},
+		},
+		{name: "缺少起算日期", override: config.PaperSchedule{EveryNDays: 3}, wantErr: true},
+		{name: "负数", override: config.PaperSchedule{EveryNDays: -1}, wantErr: true},
+		{name: "星期无效", override: config.PaperSchedule{Weekdays: []string{"周一"}}, wantErr: true},
+		{name: "休刊日期无效", override: config.PaperSchedule{Holidays: []string{"2025/11/10"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := tt.base.merge(tt.override)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("merge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, d := range tt.publish {
+				if !s.PublishesOn(d) {
+					t.Errorf("PublishesOn(%s) = false, want true", d.Format("2006-01-02"))
+				}
+			}
+			for _, d := range tt.skip {
+				if s.PublishesOn(d) {
+					t.Errorf("PublishesOn(%s) = true, want false", d.Format("2006-01-02"))
+				}
+			}
+		})
+	}
+
+	// 合并不修改注册的规则
+	if len(base.Holidays) != 1 || len(base.Weekdays) != 1 || base.Weekdays[0] != time.Friday {
+		t.Errorf("registered schedule modified: %+v", base)
+	}
+}
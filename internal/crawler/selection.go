@@ -0,0 +1,143 @@
+package crawler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// errPageSkipped 版面不在选择范围内
+var errPageSkipped = errors.New("版面未选中")
+
+// PageSelection 只下载部分版面，版面序号或版面名称匹配任一条件即下载
+type PageSelection struct {
+	Pages    []PageRange // 版面序号范围，从1开始，已排序并合并重叠和相邻的范围
+	Sections []string    // 版面名称，版面名称包含其中之一即匹配
+}
+
+// PageRange 版面序号范围，包含 First 和 Last
+// 只保存范围而不展开，1-1000000000 这样的范围不会占用大量内存
+type PageRange struct {
+	First, Last int
+}
+
+// ParsePageSelection 解析 1-4,12 格式的版面序号和逗号分隔的版面名称
+// 两者都为空时返回 nil，表示下载所有版面
+func ParsePageSelection(pages, sections string) (*PageSelection, error) {
+	s := &PageSelection{}
+
+	for _, part := range strings.Split(pages, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(part, "-")
+		first, err := strconv.Atoi(strings.TrimSpace(from))
+		last := first
+		if err == nil && isRange {
+			last, err = strconv.Atoi(strings.TrimSpace(to))
+		}
+		if err != nil || first < 1 || last < first {
+			return nil, fmt.Errorf("无效的版面范围: %s，应为 1-4,12 格式", part)
+		}
+		s.Pages = append(s.Pages, PageRange{first, last})
+	}
+	s.Pages = mergeRanges(s.Pages)
+
+	for _, name := range strings.Split(sections, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			s.Sections = append(s.Sections, name)
+		}
+	}
+
+	if len(s.Pages) == 0 && len(s.Sections) == 0 {
+		return nil, nil
+	}
+	return s, nil
+}
+
+// mergeRanges 排序并合并重叠和相邻的范围
+func mergeRanges(ranges []PageRange) []PageRange {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].First < ranges[j].First })
+
+	var merged []PageRange
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r.First <= merged[n-1].Last+1 {
+			merged[n-1].Last = max(merged[n-1].Last, r.Last)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// needsPage 判断是否需要请求第 page 版的页面
+// 只按序号选择时可以直接跳过未选中的版面，按名称选择时需要先读取版面名称
+func (s *PageSelection) needsPage(page int) bool {
+	return len(s.Sections) > 0 || s.hasPage(page)
+}
+
+// Match 判断版面是否被选中
+func (s *PageSelection) Match(page int, section string) bool {
+	if s.hasPage(page) {
+		return true
+	}
+	for _, name := range s.Sections {
+		if section != "" && strings.Contains(section, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPage 判断版面序号是否被选中
+func (s *PageSelection) hasPage(page int) bool {
+	i := sort.Search(len(s.Pages), func(i int) bool { return s.Pages[i].Last >= page })
+	return i < len(s.Pages) && s.Pages[i].First <= page
+}
+
+// ranges 返回 1-4,12 格式的版面范围
+func (s *PageSelection) ranges() []string {
+	ranges := make([]string, len(s.Pages))
+	for i, r := range s.Pages {
+		if r.First == r.Last {
+			ranges[i] = strconv.Itoa(r.First)
+		} else {
+			ranges[i] = fmt.Sprintf("%d-%d", r.First, r.Last)
+		}
+	}
+	return ranges
+}
+
+// String 返回选择条件的描述，如 第1-4,12版、要闻、评论
+func (s *PageSelection) String() string {
+	var parts []string
+	if len(s.Pages) > 0 {
+		parts = append(parts, fmt.Sprintf("第%s版", strings.Join(s.ranges(), ",")))
+	}
+	parts = append(parts, s.Sections...)
+	return strings.Join(parts, "、")
+}
+
+// fileSuffix 返回合并文件名的后缀，如 p1-4+12_要闻+评论，避免与完整的报纸混淆
+func (s *PageSelection) fileSuffix() string {
+	var parts []string
+	if len(s.Pages) > 0 {
+		parts = append(parts, "p"+strings.Join(s.ranges(), "+"))
+	}
+	if len(s.Sections) > 0 {
+		names := make([]string, len(s.Sections))
+		for i, name := range s.Sections {
+			names[i] = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(`/\:*?"<>| `, r) {
+					return '-'
+				}
+				return r
+			}, name)
+		}
+		parts = append(parts, strings.Join(names, "+"))
+	}
+	return strings.Join(parts, "_")
+}
@@ -0,0 +1,91 @@
+package crawler
+
+import "testing"
+
+func TestParsePageSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		pages    string
+		sections string
+		want     string // String() 的结果，nil 时为空
+		suffix   string
+		wantErr  bool
+	}{
+		{name: "都为空", pages: " , ", sections: ""},
+		{name: "单版", pages: "3", want: "第3版", suffix: "p3"},
+		{name: "范围和单版", pages: "12,1-4", want: "第1-4,12版", suffix: "p1-4+12"},
+		{name: "合并重叠和相邻的范围", pages: "3-6,1-4,7,9", want: "第1-7,9版", suffix: "p1-7+9"},
+		{name: "重复", pages: "2,2,2-2", want: "第2版", suffix: "p2"},
+		{name: "很大的范围", pages: "1-1000000000", want: "第1-1000000000版", suffix: "p1-1000000000"},
+		{name: "版面名称", pages: "1", sections: "要闻, 评论/观点", want: "第1版、要闻、评论/观点", suffix: "p1_要闻+评论-观点"},
+		{name: "只有版面名称", sections: "要闻", want: "要闻", suffix: "要闻"},
+		{name: "从0开始", pages: "0-4", wantErr: true},
+		{name: "范围颠倒", pages: "4-1", wantErr: true},
+		{name: "不是数字", pages: "一", wantErr: true},
+		{name: "超出整数范围", pages: "1-99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParsePageSelection(tt.pages, tt.sections)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePageSelection(%q, %q) error = %v, wantErr %v", tt.pages, tt.sections, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if s == nil {
+				if tt.want != "" {
+					t.Fatalf("ParsePageSelection(%q, %q) = nil, want %s", tt.pages, tt.sections, tt.want)
+				}
+				return
+			}
+			if got := s.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			if got := s.fileSuffix(); got != tt.suffix {
+				t.Errorf("fileSuffix() = %q, want %q", got, tt.suffix)
+			}
+		})
+	}
+}
+
+func TestPageSelectionMatch(t *testing.T) {
+	s, err := ParsePageSelection("1-4,12,100-1000000000", "要闻")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		page    int
+		section string
+		want    bool
+	}{
+		{1, "", true},
+		{4, "", true},
+		{5, "", false},
+		{11, "", false},
+		{12, "", true},
+		{13, "国际", false},
+		{99, "", false},
+		{100, "", true},
+		{999999999, "", true},
+		{1000000001, "", false},
+		{20, "要闻·二", true},
+	}
+
+	for _, tt := range tests {
+		if got := s.Match(tt.page, tt.section); got != tt.want {
+			t.Errorf("Match(%d, %q) = %v, want %v", tt.page, tt.section, got, tt.want)
+		}
+	}
+
+	// 只按序号选择时跳过未选中的版面，按名称选择时需要读取每个版面
+	pagesOnly, _ := ParsePageSelection("1-4", "")
+	if pagesOnly.needsPage(5) || !pagesOnly.needsPage(4) {
+		t.Error("needsPage() should follow the page ranges when no sections are given")
+	}
+	if !s.needsPage(50) {
+		t.Error("needsPage() = false, want true when sections are given")
+	}
+}
@@ -0,0 +1,112 @@
+package crawler
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"papers/internal/config"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// watermarkFontSize 文字水印的基准字号，实际大小由 scale 决定
+const watermarkFontSize = 48
+
+// headerMargin 页眉与页面边缘的距离，单位为点
+const headerMargin = 8
+
+// applyStamp 为合并后的PDF添加水印和每页的页眉
+// perPage 为 false 时页数与版面不对应，只添加水印
+func (c *Crawler) applyStamp(ctx *model.Context, perPage bool) error {
+	s := c.Stamp
+	fontName, err := ResolveFont(s.Font)
+	if err != nil {
+		return err
+	}
+
+	if wm := s.Watermark; wm != nil {
+		w, err := newWatermark(wm, fontName)
+		if err != nil {
+			return fmt.Errorf("创建水印失败: %v", err)
+		}
+		if err := pdfcpu.AddWatermarks(ctx, nil, w); err != nil {
+			return fmt.Errorf("添加水印失败: %v", err)
+		}
+	}
+
+	if h := s.Header; h != nil && perPage {
+		m := make(map[int]*model.Watermark, len(c.pages))
+		for i, p := range c.pages {
+			w, err := c.newHeader(h, fontName, p)
+			if err != nil {
+				return fmt.Errorf("创建页眉失败: %v", err)
+			}
+			m[i+1] = w
+		}
+		if err := pdfcpu.AddWatermarksMap(ctx, m); err != nil {
+			return fmt.Errorf("添加页眉失败: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// newWatermark 创建文字或图片水印，水印叠加在版面之上以免被整版图片遮住
+func newWatermark(wm *config.Watermark, fontName string) (*model.Watermark, error) {
+	if wm.Image != "" {
+		desc := fmt.Sprintf("opacity:%.2f, position:%s, rotation:%.0f, scalefactor:%.2f rel",
+			wm.Opacity, wm.Position, wm.Rotation, wm.Scale)
+		return api.ImageWatermark(wm.Image, desc, true, false, types.POINTS)
+	}
+
+	if err := checkFont(wm.Text, fontName); err != nil {
+		return nil, err
+	}
+	desc := fmt.Sprintf("fontname:%s, points:%d, opacity:%.2f, position:%s, rotation:%.0f, scalefactor:%.2f rel, color:%s",
+		fontName, watermarkFontSize, wm.Opacity, wm.Position, wm.Rotation, wm.Scale, wm.Color)
+	return api.TextWatermark(wm.Text, desc, true, false, types.POINTS)
+}
+
+// newHeader 创建单个版面的页眉
+func (c *Crawler) newHeader(h *config.Header, fontName string, p pageFile) (*model.Watermark, error) {
+	text := strings.NewReplacer(
+		"{paper}", PaperName(c.PaperType),
+		"{date}", c.GetDateString(),
+		"{label}", p.displayLabel(),
+		"{section}", p.section,
+	).Replace(h.Format)
+
+	if err := checkFont(text, fontName); err != nil {
+		return nil, err
+	}
+
+	// 靠上或靠下时向页面内侧偏移，避免贴边被裁切
+	offset := 0
+	switch {
+	case strings.HasPrefix(h.Position, "t"):
+		offset = -headerMargin
+	case strings.HasPrefix(h.Position, "b"):
+		offset = headerMargin
+	}
+
+	desc := fmt.Sprintf("fontname:%s, points:%d, opacity:%.2f, position:%s, offset:0 %d, rotation:0, scalefactor:1 abs, color:%s",
+		fontName, h.FontSize, h.Opacity, h.Position, offset, h.Color)
+	return api.TextWatermark(text, desc, true, false, types.POINTS)
+}
+
+// checkFont 检查文字是否需要中文字体
+func checkFont(text, fontName string) error {
+	if fontName != defaultFont {
+		return nil
+	}
+	for _, r := range text {
+		if r > unicode.MaxASCII {
+			return fmt.Errorf("文字 %q 包含中文，需要在配置中指定中文字体 (font)", text)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package crawler
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+)
+
+// maxProbeTiles 探测瓦片网格尺寸时单个方向的最大瓦片数
+const maxProbeTiles = 64
+
+// TileLevel 某个缩放级别下的瓦片网格
+// Cols 或 Rows 为0时，爬虫会逐个请求瓦片探测网格尺寸
+type TileLevel struct {
+	Zoom int
+	Cols int
+	Rows int
+}
+
+// TileSet 描述一个由缩放查看器的瓦片拼接而成的版面图片
+type TileSet struct {
+	Levels []TileLevel
+	// TileURL 返回指定缩放级别下第 col 列、第 row 行瓦片的地址，行列均从0开始
+	TileURL func(zoom, col, row int) string
+}
+
+// NewTiledAsset 创建瓦片资源，爬虫会下载最高缩放级别的全部瓦片并拼接为一张图片
+func NewTiledAsset(tiles *TileSet) *PageAsset {
+	return &PageAsset{Kind: AssetTiles, Tiles: tiles}
+}
+
+// maxLevel 返回缩放级别最高的网格
+func (t *TileSet) maxLevel() (TileLevel, error) {
+	if len(t.Levels) == 0 || t.TileURL == nil {
+		return TileLevel{}, fmt.Errorf("瓦片资源缺少缩放级别或地址")
+	}
+	best := t.Levels[0]
+	for _, l := range t.Levels[1:] {
+		if l.Zoom > best.Zoom {
+			best = l
+		}
+	}
+	return best, nil
+}
+
+// stitch 下载最高缩放级别的全部瓦片并拼接，返回编码后的图片数据
+// 瓦片为JPEG时以高质量JPEG输出，否则输出无损PNG
+func (t *TileSet) stitch(headers http.Header) ([]byte, error) {
+	level, err := t.maxLevel()
+	if err != nil {
+		return nil, err
+	}
+
+	if level.Cols == 0 {
+		level.Cols = t.probe(level.Zoom, headers, func(i int) (int, int) { return i, 0 })
+	}
+	if level.Rows == 0 {
+		level.Rows = t.probe(level.Zoom, headers, func(i int) (int, int) { return 0, i })
+	}
+	if level.Cols == 0 || level.Rows == 0 {
+		return nil, fmt.Errorf("未找到缩放级别 %d 的瓦片", level.Zoom)
+	}
+
+	fmt.Printf("拼接瓦片: 缩放级别 %d, %d 列 x %d 行\n", level.Zoom, level.Cols, level.Rows)
+
+	// 下载所有瓦片
+	tiles := make([][]image.Image, level.Rows)
+	format := ""
+	for row := 0; row < level.Rows; row++ {
+		tiles[row] = make([]image.Image, level.Cols)
+		for col := 0; col < level.Cols; col++ {
+			img, f, err := t.fetchTile(level.Zoom, col, row, headers)
+			if err != nil {
+				return nil, fmt.Errorf("下载瓦片 (%d,%d) 失败: %v", col, row, err)
+			}
+			tiles[row][col] = img
+			if format == "" {
+				format = f
+			}
+		}
+	}
+
+	// 列宽取第一行瓦片的宽度，行高取第一列瓦片的高度，边缘瓦片可能更小
+	xs := make([]int, level.Cols+1)
+	for col := 0; col < level.Cols; col++ {
+		xs[col+1] = xs[col] + tiles[0][col].Bounds().Dx()
+	}
+	ys := make([]int, level.Rows+1)
+	for row := 0; row < level.Rows; row++ {
+		ys[row+1] = ys[row] + tiles[row][0].Bounds().Dy()
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, xs[level.Cols], ys[level.Rows]))
+	for row := 0; row < level.Rows; row++ {
+		for col := 0; col < level.Cols; col++ {
+			tile := tiles[row][col]
+			r := image.Rect(xs[col], ys[row], xs[col+1], ys[row+1])
+			draw.Draw(dst, r, tile, tile.Bounds().Min, draw.Src)
+		}
+	}
+
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 95})
+	} else {
+		err = png.Encode(&buf, dst)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("编码拼接图片失败: %v", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// probe 沿一个方向逐个请求瓦片，返回连续存在的瓦片数量
+func (t *TileSet) probe(zoom int, headers http.Header, pos func(i int) (col, row int)) int {
+	for i := 0; i < maxProbeTiles; i++ {
+		col, row := pos(i)
+		resp, err := httpGet(t.TileURL(zoom, col, row), headers)
+		if err != nil {
+			return i
+		}
+		resp.Body.Close()
+	}
+	return maxProbeTiles
+}
+
+// fetchTile 下载并解码单个瓦片
+func (t *TileSet) fetchTile(zoom, col, row int, headers http.Header) (image.Image, string, error) {
+	resp, err := httpGet(t.TileURL(zoom, col, row), headers)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	return image.Decode(resp.Body)
+}
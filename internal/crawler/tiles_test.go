@@ -0,0 +1,131 @@
+package crawler
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// 测试用的瓦片网格：3列2行，普通瓦片 64x64，最右一列宽 30，最下一行高 20
+const (
+	tileCols, tileRows = 3, 2
+	tileSize           = 64
+	lastColW, lastRowH = 30, 20
+)
+
+// tileColor 每个瓦片填充不同的颜色，用于检查拼接位置
+func tileColor(col, row int) color.RGBA {
+	return color.RGBA{R: uint8(40 * (col + 1)), G: uint8(80 * (row + 1)), B: 7, A: 255}
+}
+
+// tileServer 提供缩放级别 2 的瓦片，要求带 Referer 请求头
+func tileServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Referer") != "https://example.com/page" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		var zoom, col, row int
+		if _, err := fmt.Sscanf(r.URL.Path, "/tiles/%d/%d_%d.png", &zoom, &col, &row); err != nil ||
+			zoom != 2 || col >= tileCols || row >= tileRows {
+			http.NotFound(w, r)
+			return
+		}
+
+		w2, h2 := tileSize, tileSize
+		if col == tileCols-1 {
+			w2 = lastColW
+		}
+		if row == tileRows-1 {
+			h2 = lastRowH
+		}
+		img := image.NewRGBA(image.Rect(0, 0, w2, h2))
+		c := tileColor(col, row)
+		for y := 0; y < h2; y++ {
+			for x := 0; x < w2; x++ {
+				img.SetRGBA(x, y, c)
+			}
+		}
+		png.Encode(w, img)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestTiledAsset(t *testing.T) {
+	srv := tileServer(t)
+	tileURL := func(zoom, col, row int) string {
+		return fmt.Sprintf("%s/tiles/%d/%d_%d.png", srv.URL, zoom, col, row)
+	}
+
+	tests := []struct {
+		name    string
+		levels  []TileLevel
+		referer string
+		wantErr bool
+	}{
+		{name: "已知网格", levels: []TileLevel{{Zoom: 1, Cols: 2, Rows: 1}, {Zoom: 2, Cols: tileCols, Rows: tileRows}}, referer: "https://example.com/page"},
+		{name: "探测网格", levels: []TileLevel{{Zoom: 2}}, referer: "https://example.com/page"},
+		{name: "缩放级别不存在", levels: []TileLevel{{Zoom: 3}}, referer: "https://example.com/page", wantErr: true},
+		{name: "缺少请求头", levels: []TileLevel{{Zoom: 2, Cols: tileCols, Rows: tileRows}}, wantErr: true},
+		{name: "没有缩放级别", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asset := NewTiledAsset(&TileSet{Levels: tt.levels, TileURL: tileURL})
+			if tt.referer != "" {
+				asset = asset.WithHeader("Referer", tt.referer)
+			}
+
+			data, err := asset.read()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if asset.isPDF(data) {
+				t.Fatal("stitched tiles treated as PDF")
+			}
+
+			img, format, err := image.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if format != "png" {
+				t.Errorf("format = %s, want png", format)
+			}
+			wantW, wantH := tileSize*(tileCols-1)+lastColW, tileSize*(tileRows-1)+lastRowH
+			if b := img.Bounds(); b.Dx() != wantW || b.Dy() != wantH {
+				t.Fatalf("size = %dx%d, want %dx%d", b.Dx(), b.Dy(), wantW, wantH)
+			}
+
+			// 检查每个瓦片的左上角和右下角
+			for row := 0; row < tileRows; row++ {
+				for col := 0; col < tileCols; col++ {
+					x0, y0 := col*tileSize, row*tileSize
+					x1, y1 := x0+tileSize-1, y0+tileSize-1
+					if col == tileCols-1 {
+						x1 = x0 + lastColW - 1
+					}
+					if row == tileRows-1 {
+						y1 = y0 + lastRowH - 1
+					}
+					want := tileColor(col, row)
+					for _, p := range []image.Point{{x0, y0}, {x1, y1}} {
+						if got := color.RGBAModel.Convert(img.At(p.X, p.Y)); got != want {
+							t.Errorf("pixel %v of tile (%d,%d) = %v, want %v", p, col, row, got, want)
+						}
+					}
+				}
+			}
+		})
+	}
+}
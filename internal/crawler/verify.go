@@ -0,0 +1,44 @@
+package crawler
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// ValidatePDF 使用pdfcpu校验PDF文件的结构，返回页数
+// 截断或损坏的文件会校验失败
+func ValidatePDF(path string) (int, error) {
+	conf := model.NewDefaultConfiguration()
+	conf.ValidationMode = model.ValidationRelaxed
+
+	if err := api.ValidateFile(path, conf); err != nil {
+		return 0, err
+	}
+
+	pages, err := api.PageCountFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("读取页数失败: %v", err)
+	}
+	return pages, nil
+}
+
+// KeepFrontPage 将PDF文件裁剪为只包含头版，跳过合并时插入的封面和目录
+// 先写入临时文件再替换，避免中断时损坏原文件
+func KeepFrontPage(path string) error {
+	ctx, err := api.ReadContextFile(path)
+	if err != nil {
+		return err
+	}
+	front := strconv.Itoa(coverPages(ctx) + 1)
+
+	tmp := path + ".tmp"
+	if err := api.TrimFile(path, tmp, []string{front}, model.NewDefaultConfiguration()); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,106 @@
+package crawler
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+// writeTestPDF 生成每页宽度不同的PDF，第 i 页宽 100*i 点，用于确认保留了哪一页
+func writeTestPDF(t *testing.T, path string, n int, annotate func(ctx *model.Context) error) {
+	t.Helper()
+	var pages []*imagePage
+	for i := 1; i <= n; i++ {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 100*i, 100)), nil); err != nil {
+			t.Fatal(err)
+		}
+		p, err := decodeImagePage(buf.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.dpi = 72
+		pages = append(pages, p)
+	}
+	if err := writeImagePDFFile(path, pages, DefaultImageOptions()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, err := api.ReadContextFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := annotate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.WriteContextFile(ctx, path); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKeepFrontPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		annotate func(ctx *model.Context) error
+		want     int // 保留的原页码
+	}{
+		{
+			name:     "没有封面",
+			annotate: func(ctx *model.Context) error { return setMetadata(ctx, Metadata{Title: "测试"}) },
+			want:     1,
+		},
+		{
+			name: "文档信息记录封面页数",
+			annotate: func(ctx *model.Context) error {
+				return setMetadata(ctx, Metadata{Title: "测试", CoverPages: 2})
+			},
+			want: 3,
+		},
+		{
+			name: "按页码标签识别封面",
+			annotate: func(ctx *model.Context) error {
+				return setPageLabels(ctx, []string{"封面", "目录", "01", "02"})
+			},
+			want: 3,
+		},
+		{
+			name: "页码标签不是封面",
+			annotate: func(ctx *model.Context) error {
+				return setPageLabels(ctx, []string{"A1", "A2", "A3", "A4"})
+			},
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rmrb_20251110.pdf")
+			writeTestPDF(t, path, 4, tt.annotate)
+
+			if err := KeepFrontPage(path); err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, err := api.ReadContextFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ctx.PageCount != 1 {
+				t.Fatalf("PageCount = %d, want 1", ctx.PageCount)
+			}
+			dims, err := ctx.PageDims()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := int(math.Round(dims[0].Width / 100)); got != tt.want {
+				t.Errorf("kept page %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
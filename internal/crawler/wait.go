@@ -0,0 +1,63 @@
+package crawler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WaitOptions 等待报纸发布的轮询参数
+type WaitOptions struct {
+	Deadline    time.Time     // 截止时间，到达后放弃等待
+	Interval    time.Duration // 初始轮询间隔
+	MaxInterval time.Duration // 退避后的最大轮询间隔
+}
+
+// Available 检查首个版面页面是否已发布
+func (c *Crawler) Available() error {
+	resp, err := http.Get(c.Fetcher.BuildURL(1))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP状态码: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// WaitForPublication 轮询首个版面直到报纸发布或到达截止时间
+// 每次未发布后轮询间隔翻倍，直到 MaxInterval，并将该次轮询的时间记为 LastMissing
+func (c *Crawler) WaitForPublication(opts WaitOptions) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	for {
+		at := time.Now()
+		err := c.Available()
+		if err == nil {
+			return nil
+		}
+		c.LastMissing = at
+
+		remaining := time.Until(opts.Deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("截止时间 %s 前未发布: %v", opts.Deadline.Format("15:04"), err)
+		}
+
+		wait := interval
+		if wait > remaining {
+			wait = remaining
+		}
+		fmt.Printf("[%s] 尚未发布 (%v)，%s 后重试\n", c.PaperType, err, wait.Round(time.Second))
+		time.Sleep(wait)
+
+		interval *= 2
+		if opts.MaxInterval > 0 && interval > opts.MaxInterval {
+			interval = opts.MaxInterval
+		}
+	}
+}
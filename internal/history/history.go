@@ -0,0 +1,242 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	// retentionDays 保留最近多少天的观察记录
+	retentionDays = 120
+	// profileSize 每个星期参与统计的最近观察次数
+	profileSize = 8
+	// minSamples 某个星期的观察次数少于该值时，改用所有星期的记录统计
+	minSamples = 3
+)
+
+// shanghai 报纸发布时间统一按东8区统计
+var shanghai = func() *time.Location {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		return time.FixedZone("CST", 8*3600)
+	}
+	return loc
+}()
+
+// History 各报纸的发布时间记录
+type History struct {
+	Papers map[string]*PaperHistory `json:"papers"`
+}
+
+// PaperHistory 单份报纸的发布时间记录
+// 首次可用时间只是发布时间的上限，只有之前观察到尚未发布时，才能确定发布时间所在的区间
+type PaperHistory struct {
+	// FirstSeen 每期报纸（键为出版日期 2006-01-02）首次被观察到可用的时间
+	FirstSeen map[string]time.Time `json:"first_seen"`
+	// LastMissing 每期报纸在首次可用之前最后一次被观察到尚未发布的时间
+	LastMissing map[string]time.Time `json:"last_missing,omitempty"`
+}
+
+// Profile 根据历史记录推算的发布时间
+type Profile struct {
+	Expected time.Duration // 距当天零点的时长
+	Samples  int           // 参与统计的观察次数
+	Weekday  bool          // 是否按星期统计
+}
+
+// Clock 返回 HH:MM 格式的预计发布时间
+func (p Profile) Clock() string {
+	m := int(p.Expected.Minutes())
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
+
+// String 返回预计发布时间的描述
+func (p Profile) String() string {
+	scope := "所有星期"
+	if p.Weekday {
+		scope = "同星期"
+	}
+	return fmt.Sprintf("%s（基于%s最近 %d 次记录）", p.Clock(), scope, p.Samples)
+}
+
+// New 创建空的发布时间记录
+func New() *History {
+	return &History{Papers: make(map[string]*PaperHistory)}
+}
+
+// Load 读取记录文件，文件不存在时返回空记录
+func Load(path string) (*History, error) {
+	h := New()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取发布时间记录失败: %v", err)
+	}
+
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("解析发布时间记录 %s 失败: %v", path, err)
+	}
+	if h.Papers == nil {
+		h.Papers = make(map[string]*PaperHistory)
+	}
+	return h, nil
+}
+
+// Save 写入记录文件
+func (h *History) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Observe 记录某期报纸在 at 时刻已经可用
+// 只记录出版当天的观察，补下载历史报纸不影响统计；同一期只保留最早的时间
+func (h *History) Observe(code string, edition, at time.Time) bool {
+	at = at.In(shanghai)
+	day := edition.In(shanghai).Format("2006-01-02")
+	if at.Format("2006-01-02") != day {
+		return false
+	}
+
+	ph := h.paper(code)
+	if seen, ok := ph.FirstSeen[day]; ok && !at.Before(seen) {
+		return false
+	}
+	ph.FirstSeen[day] = at
+	ph.prune(at)
+	return true
+}
+
+// ObserveMissing 记录某期报纸在 at 时刻尚未发布，作为发布时间的下限
+// 只记录出版当天、首次可用之前的观察；同一期只保留最晚的时间
+func (h *History) ObserveMissing(code string, edition, at time.Time) bool {
+	at = at.In(shanghai)
+	day := edition.In(shanghai).Format("2006-01-02")
+	if at.Format("2006-01-02") != day {
+		return false
+	}
+
+	ph := h.paper(code)
+	if seen, ok := ph.FirstSeen[day]; ok && !at.Before(seen) {
+		return false
+	}
+	if missing, ok := ph.LastMissing[day]; ok && !at.After(missing) {
+		return false
+	}
+	ph.LastMissing[day] = at
+	ph.prune(at)
+	return true
+}
+
+// paper 返回报纸的记录，不存在时创建
+func (h *History) paper(code string) *PaperHistory {
+	ph, ok := h.Papers[code]
+	if !ok {
+		ph = &PaperHistory{}
+		h.Papers[code] = ph
+	}
+	if ph.FirstSeen == nil {
+		ph.FirstSeen = make(map[string]time.Time)
+	}
+	if ph.LastMissing == nil {
+		ph.LastMissing = make(map[string]time.Time)
+	}
+	return ph
+}
+
+// prune 删除超出保留期限的记录
+func (ph *PaperHistory) prune(now time.Time) {
+	cutoff := now.AddDate(0, 0, -retentionDays).Format("2006-01-02")
+	for day := range ph.FirstSeen {
+		if day < cutoff {
+			delete(ph.FirstSeen, day)
+		}
+	}
+	for day := range ph.LastMissing {
+		if day < cutoff {
+			delete(ph.LastMissing, day)
+		}
+	}
+}
+
+// published 返回某期报纸的发布时间估计，取尚未发布和首次可用两次观察的中点
+// 没有观察到尚未发布时，首次可用时间可能远晚于实际发布时间，不参与统计
+func (ph *PaperHistory) published(day string) (time.Time, bool) {
+	seen, ok := ph.FirstSeen[day]
+	if !ok {
+		return time.Time{}, false
+	}
+	missing, ok := ph.LastMissing[day]
+	if !ok || !missing.Before(seen) {
+		return time.Time{}, false
+	}
+	return missing.Add(seen.Sub(missing) / 2), true
+}
+
+// Expected 推算报纸在指定星期的发布时间
+// 取同星期最近几次发布时间估计的中位数，记录不足时使用所有星期的记录
+func (h *History) Expected(code string, weekday time.Weekday) (Profile, bool) {
+	ph, ok := h.Papers[code]
+	if !ok {
+		return Profile{}, false
+	}
+
+	days := make([]string, 0, len(ph.FirstSeen))
+	for day := range ph.FirstSeen {
+		days = append(days, day)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(days)))
+
+	var same, all []time.Duration
+	for _, day := range days {
+		t, ok := ph.published(day)
+		if !ok {
+			continue
+		}
+		t = t.In(shanghai)
+		offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+		if len(all) < profileSize {
+			all = append(all, offset)
+		}
+		if t.Weekday() == weekday && len(same) < profileSize {
+			same = append(same, offset)
+		}
+	}
+	if len(all) == 0 {
+		return Profile{}, false
+	}
+
+	if len(same) >= minSamples {
+		return Profile{Expected: median(same), Samples: len(same), Weekday: true}, true
+	}
+	return Profile{Expected: median(all), Samples: len(all)}, true
+}
+
+// median 返回中位数
+func median(values []time.Duration) time.Duration {
+	sorted := append([]time.Duration(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
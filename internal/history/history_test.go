@@ -0,0 +1,184 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+// at 返回东8区 day 当天 clock 时刻
+func at(t *testing.T, day, clock string) time.Time {
+	t.Helper()
+	ts, err := time.ParseInLocation("2006-01-02 15:04", day+" "+clock, shanghai)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts
+}
+
+// observation 一期报纸的观察，missing 为空表示没有观察到尚未发布
+type observation struct {
+	day, missing, seen string
+}
+
+// record 依次记录观察
+func record(t *testing.T, h *History, code string, obs []observation) {
+	t.Helper()
+	for _, o := range obs {
+		edition := at(t, o.day, "00:00")
+		if o.missing != "" {
+			h.ObserveMissing(code, edition, at(t, o.day, o.missing))
+		}
+		h.Observe(code, edition, at(t, o.day, o.seen))
+	}
+}
+
+func TestObserve(t *testing.T) {
+	edition := at(t, "2025-11-10", "00:00")
+
+	tests := []struct {
+		name        string
+		observe     func(h *History) bool
+		wantSeen    string
+		wantMissing string
+	}{
+		{
+			name:     "首次可用",
+			observe:  func(h *History) bool { return h.Observe("rmrb", edition, at(t, "2025-11-10", "05:30")) },
+			wantSeen: "05:30",
+		},
+		{
+			name: "保留最早的可用时间",
+			observe: func(h *History) bool {
+				h.Observe("rmrb", edition, at(t, "2025-11-10", "05:30"))
+				return h.Observe("rmrb", edition, at(t, "2025-11-10", "05:20"))
+			},
+			wantSeen: "05:20",
+		},
+		{
+			name: "较晚的可用时间不更新",
+			observe: func(h *History) bool {
+				h.Observe("rmrb", edition, at(t, "2025-11-10", "05:30"))
+				return !h.Observe("rmrb", edition, at(t, "2025-11-10", "07:00"))
+			},
+			wantSeen: "05:30",
+		},
+		{
+			name:    "补下载历史报纸不记录",
+			observe: func(h *History) bool { return !h.Observe("rmrb", edition, at(t, "2025-11-11", "05:30")) },
+		},
+		{
+			name: "保留最晚的未发布时间",
+			observe: func(h *History) bool {
+				h.ObserveMissing("rmrb", edition, at(t, "2025-11-10", "05:00"))
+				return h.ObserveMissing("rmrb", edition, at(t, "2025-11-10", "05:10"))
+			},
+			wantMissing: "05:10",
+		},
+		{
+			name: "可用之后的未发布不记录",
+			observe: func(h *History) bool {
+				h.Observe("rmrb", edition, at(t, "2025-11-10", "05:30"))
+				return !h.ObserveMissing("rmrb", edition, at(t, "2025-11-10", "06:00"))
+			},
+			wantSeen: "05:30",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New()
+			if !tt.observe(h) {
+				t.Fatal("unexpected Observe result")
+			}
+
+			var seen, missing string
+			if ph, ok := h.Papers["rmrb"]; ok {
+				if ts, ok := ph.FirstSeen["2025-11-10"]; ok {
+					seen = ts.In(shanghai).Format("15:04")
+				}
+				if ts, ok := ph.LastMissing["2025-11-10"]; ok {
+					missing = ts.In(shanghai).Format("15:04")
+				}
+			}
+			if seen != tt.wantSeen {
+				t.Errorf("FirstSeen = %q, want %q", seen, tt.wantSeen)
+			}
+			if missing != tt.wantMissing {
+				t.Errorf("LastMissing = %q, want %q", missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestExpected(t *testing.T) {
+	// 2025-11-10 为星期一
+	bounded := []observation{
+		{"2025-11-03", "05:00", "05:20"},
+		{"2025-11-10", "05:05", "05:15"},
+		{"2025-11-17", "04:50", "05:30"},
+	}
+
+	tests := []struct {
+		name    string
+		obs     []observation
+		weekday time.Weekday
+		want    string
+		samples int
+		weekly  bool
+		ok      bool
+	}{
+		{name: "没有记录", weekday: time.Monday},
+		{
+			name:    "只有首次可用时间",
+			obs:     []observation{{"2025-11-03", "", "05:20"}, {"2025-11-10", "", "06:00"}},
+			weekday: time.Monday,
+		},
+		{
+			name:    "取区间中点的中位数",
+			obs:     bounded,
+			weekday: time.Monday,
+			want:    "05:10", samples: 3, weekly: true, ok: true,
+		},
+		{
+			// 定时任务在建议时间下载成功，只得到上限，不应推迟预计时间
+			name: "按时成功不推迟预计时间",
+			obs: append([]observation{
+				{"2025-10-13", "", "05:25"},
+				{"2025-10-20", "", "05:30"},
+				{"2025-10-27", "", "05:35"},
+				{"2025-11-24", "", "05:40"},
+			}, bounded...),
+			weekday: time.Monday,
+			want:    "05:10", samples: 3, weekly: true, ok: true,
+		},
+		{
+			name:    "同星期记录不足时使用所有星期",
+			obs:     append([]observation{{"2025-11-11", "06:00", "06:20"}}, bounded...),
+			weekday: time.Tuesday,
+			want:    "05:10", samples: 4, ok: true,
+		},
+		{
+			name:    "可用时间早于未发布时间",
+			obs:     []observation{{"2025-11-10", "", "05:00"}, {"2025-11-10", "05:30", "05:40"}},
+			weekday: time.Monday,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := New()
+			record(t, h, "rmrb", tt.obs)
+
+			p, ok := h.Expected("rmrb", tt.weekday)
+			if ok != tt.ok {
+				t.Fatalf("Expected() ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if p.Clock() != tt.want || p.Samples != tt.samples || p.Weekday != tt.weekly {
+				t.Errorf("Expected() = %s/%d/%v, want %s/%d/%v", p.Clock(), p.Samples, p.Weekday, tt.want, tt.samples, tt.weekly)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"papers/internal/crawler"
 	"strings"
 	"time"
 
@@ -71,8 +72,8 @@ func (f *Fetcher) GetPageCount(url string) (int, error) {
 	return count, nil
 }
 
-// FindPDFURL 从页面中查找PDF下载链接
-func (f *Fetcher) FindPDFURL(doc *goquery.Document, baseURL string) (string, error) {
+// FindAsset 从页面中查找PDF下载链接
+func (f *Fetcher) FindAsset(doc *goquery.Document, baseURL string) (*crawler.PageAsset, error) {
 	var pdfURL string
 
 	// 查找PDF链接
@@ -95,20 +96,20 @@ func (f *Fetcher) FindPDFURL(doc *goquery.Document, baseURL string) (string, err
 	}
 
 	if pdfURL == "" {
-		return "", fmt.Errorf("未找到PDF链接")
+		return nil, fmt.Errorf("未找到PDF链接")
 	}
 
 	// 如果是相对路径，使用url.Parse解析
 	if !strings.HasPrefix(pdfURL, "http") {
 		base, err := url.Parse(baseURL)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		// 处理相对路径
 		pdfPath, err := url.Parse(pdfURL)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		// 解析相对URL
@@ -116,5 +117,8 @@ func (f *Fetcher) FindPDFURL(doc *goquery.Document, baseURL string) (string, err
 		pdfURL = fullURL.String()
 	}
 
-	return pdfURL, nil
+	// 版次、版面名称和文章标题用于合并后PDF的书签、文档信息和目录页
+	return crawler.NewPDFAsset(pdfURL).
+		WithHeading(crawler.FindPageHeading(doc)).
+		WithHeadlines(crawler.FindHeadlines(doc)), nil
 }
@@ -2,6 +2,7 @@ package people
 
 import (
 	"papers/internal/crawler"
+	"time"
 )
 
 // NewCrawler 创建新的人民日报系列爬虫实例
@@ -21,3 +22,31 @@ func NewCrawler(paperType, dateStr string) (*crawler.Crawler, error) {
 	// 使用正确的Fetcher重新创建爬虫
 	return crawler.NewCrawler(paperType, fetcher, dateStr)
 }
+
+// papers 人民日报系列支持的报纸，按默认下载顺序排列
+var papers = []struct {
+	code     string
+	name     string
+	schedule *crawler.PublicationSchedule
+}{
+	{"rmrb", "人民日报", nil},
+	{"jksb", "健康时报", crawler.Weekly(time.Tuesday, time.Friday)},
+	{"zgcsb", "中国城市报", crawler.Weekly(time.Monday)},
+	{"fcyym", "讽刺与幽默", crawler.Weekly(time.Friday)},
+}
+
+func init() {
+	for _, p := range papers {
+		code := p.code
+		crawler.Register(&crawler.Paper{
+			Code:      code,
+			Name:      p.name,
+			Family:    "people",
+			Schedule:  p.schedule,
+			Publisher: "人民日报社",
+			NewFetcher: func(date time.Time) crawler.PaperFetcher {
+				return NewFetcher(code, date)
+			},
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package retention
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"papers/internal/config"
+)
+
+// Action 对一期报纸的清理操作
+type Action int
+
+const (
+	Keep      Action = iota // 保留
+	Delete                  // 删除文件
+	FrontPage               // 只保留头版
+)
+
+// File 归档中的一期报纸
+type File struct {
+	Paper      string
+	Family     string
+	Date       time.Time
+	Path       string   // 合并文件，只剩派生文件时为空
+	Extra      []string // 部分版面、打印版、手机阅读版等派生文件，随合并文件一起清理
+	Size       int64    // 合并文件和派生文件的总大小
+	FrontPage  bool     // 已经只保留了头版
+	Collection bool     // 多份报纸的合集，Paper 为文件名前缀
+}
+
+// Paths 返回这一期的所有文件
+func (f File) Paths() []string {
+	if f.Path == "" {
+		return f.Extra
+	}
+	return append([]string{f.Path}, f.Extra...)
+}
+
+// Decision 对一期报纸的清理决定
+type Decision struct {
+	File   File
+	Action Action
+	Reason string
+}
+
+// Policy 解析后的保留策略
+type Policy struct {
+	pins  map[string]bool
+	rules []*rule
+}
+
+// rule 解析后的保留规则
+type rule struct {
+	config.RetentionRule
+	papers   map[string]bool
+	weekdays map[time.Weekday]bool
+	maxSize  int64
+}
+
+// NewPolicy 校验并解析保留策略
+func NewPolicy(cfg config.Retention) (*Policy, error) {
+	p := &Policy{pins: make(map[string]bool)}
+	for _, pin := range cfg.Pins {
+		p.pins[strings.TrimSpace(pin)] = true
+	}
+
+	for i, rc := range cfg.Rules {
+		r := &rule{RetentionRule: rc, papers: make(map[string]bool), weekdays: make(map[time.Weekday]bool)}
+		for _, code := range rc.Papers {
+			r.papers[code] = true
+		}
+		for _, w := range rc.Weekdays {
+			d, err := config.ParseWeekday(w)
+			if err != nil {
+				return nil, fmt.Errorf("保留规则 %d: %v", i+1, err)
+			}
+			r.weekdays[d] = true
+		}
+		if rc.MaxSize != "" {
+			size, err := ParseSize(rc.MaxSize)
+			if err != nil {
+				return nil, fmt.Errorf("保留规则 %d: %v", i+1, err)
+			}
+			r.maxSize = size
+		}
+		p.rules = append(p.rules, r)
+	}
+	return p, nil
+}
+
+// match 判断规则是否适用于报纸
+func (r *rule) match(f File) bool {
+	if len(r.papers) == 0 && r.Family == "" {
+		return true
+	}
+	return r.papers[f.Paper] || (r.Family != "" && r.Family == f.Family)
+}
+
+// Pinned 判断报纸是否在永不清理的列表中
+func (p *Policy) Pinned(f File) bool {
+	return p.pins[f.Paper] || p.pins[f.Paper+"/"+f.Date.Format("2006-01-02")]
+}
+
+// Plan 按保留策略决定每期报纸的清理操作，只返回需要清理的报纸
+// today 为当天日期，用于计算每期报纸的天数
+func (p *Policy) Plan(files []File, today time.Time) []Decision {
+	groups := make(map[*rule][]File)
+	for _, f := range files {
+		if p.Pinned(f) {
+			continue
+		}
+		for _, r := range p.rules {
+			if r.match(f) {
+				groups[r] = append(groups[r], f)
+				break
+			}
+		}
+	}
+
+	var decisions []Decision
+	for _, r := range p.rules {
+		decisions = append(decisions, r.plan(groups[r], today)...)
+	}
+
+	sort.SliceStable(decisions, func(i, j int) bool {
+		return decisions[i].File.Date.Before(decisions[j].File.Date)
+	})
+	return decisions
+}
+
+// plan 对适用同一规则的报纸决定清理操作
+func (r *rule) plan(files []File, today time.Time) []Decision {
+	// 从新到旧处理，总大小超出上限时删除较旧的报纸
+	sort.Slice(files, func(i, j int) bool { return files[i].Date.After(files[j].Date) })
+
+	var decisions []Decision
+	var total int64
+	for _, f := range files {
+		age := days(f.Date, today)
+
+		switch {
+		case r.KeepDays > 0 && age >= r.KeepDays:
+			decisions = append(decisions, Decision{File: f, Action: Delete, Reason: fmt.Sprintf("只保留最近 %d 天", r.KeepDays)})
+			continue
+		case r.WeekdaysAfterDays > 0 && age >= r.WeekdaysAfterDays && !r.weekdays[f.Date.Weekday()]:
+			decisions = append(decisions, Decision{File: f, Action: Delete, Reason: fmt.Sprintf("%d 天后只保留每周%s", r.WeekdaysAfterDays, r.weekdayNames())})
+			continue
+		}
+
+		if r.maxSize > 0 && total+f.Size > r.maxSize {
+			decisions = append(decisions, Decision{File: f, Action: Delete, Reason: fmt.Sprintf("超出总大小上限 %s", r.MaxSize)})
+			continue
+		}
+		total += f.Size
+
+		if r.FrontPageAfterDays > 0 && age >= r.FrontPageAfterDays {
+			reason := fmt.Sprintf("%d 天后只保留头版", r.FrontPageAfterDays)
+			switch {
+			case f.Collection || f.Path == "":
+				// 合集和派生文件没有单独的头版，直接删除
+				decisions = append(decisions, Decision{File: f, Action: Delete, Reason: reason})
+			case !f.FrontPage || len(f.Extra) > 0:
+				decisions = append(decisions, Decision{File: f, Action: FrontPage, Reason: reason})
+			}
+		}
+	}
+	return decisions
+}
+
+// weekdayNames 返回规则保留的星期，如 一、四
+func (r *rule) weekdayNames() string {
+	names := []string{"日", "一", "二", "三", "四", "五", "六"}
+	var days []string
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if r.weekdays[d] {
+			days = append(days, names[d])
+		}
+	}
+	return strings.Join(days, "、")
+}
+
+// days 返回 date 距 today 的天数，只比较日期
+func days(date, today time.Time) int {
+	d := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	t := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	return int(t.Sub(d).Hours() / 24)
+}
+
+// sizeUnits 大小单位
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1},
+}
+
+// ParseSize 解析 10GB、500MB 等格式的大小
+func ParseSize(s string) (int64, error) {
+	v := strings.ToUpper(strings.TrimSpace(s))
+	for _, u := range sizeUnits {
+		if strings.HasSuffix(v, u.suffix) {
+			n, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(v, u.suffix)), 64)
+			if err != nil || n < 0 {
+				break
+			}
+			return int64(n * float64(u.bytes)), nil
+		}
+	}
+	return 0, fmt.Errorf("无效的大小: %s，应为 500MB、10GB 等格式", s)
+}
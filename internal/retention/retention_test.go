@@ -0,0 +1,173 @@
+package retention
+
+import (
+	"testing"
+	"time"
+
+	"papers/internal/config"
+)
+
+// today 2025-11-10，星期一
+var today = time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+
+// ago 返回 today 之前 n 天的报纸
+func ago(paper string, n int) File {
+	date := today.AddDate(0, 0, -n)
+	return File{Paper: paper, Date: date, Path: paper + "_" + date.Format("20060102") + ".pdf", Size: 100}
+}
+
+func with(f File, fn func(*File)) File {
+	fn(&f)
+	return f
+}
+
+func TestPlan(t *testing.T) {
+	type want struct {
+		paper  string
+		age    int
+		action Action
+	}
+
+	tests := []struct {
+		name   string
+		config config.Retention
+		files  []File
+		want   []want
+	}{
+		{
+			name:   "只保留最近N天",
+			config: config.Retention{Rules: []config.RetentionRule{{KeepDays: 30}}},
+			files:  []File{ago("rmrb", 29), ago("rmrb", 30), ago("rmrb", 40)},
+			want:   []want{{"rmrb", 40, Delete}, {"rmrb", 30, Delete}},
+		},
+		{
+			name:   "N天后只保留周一",
+			config: config.Retention{Rules: []config.RetentionRule{{WeekdaysAfterDays: 7, Weekdays: []string{"mon"}}}},
+			files:  []File{ago("rmrb", 6), ago("rmrb", 7), ago("rmrb", 8), ago("rmrb", 14)},
+			want:   []want{{"rmrb", 8, Delete}},
+		},
+		{
+			name:   "总大小上限删除较旧的报纸",
+			config: config.Retention{Rules: []config.RetentionRule{{MaxSize: "250B"}}},
+			files:  []File{ago("rmrb", 1), ago("rmrb", 2), ago("rmrb", 3)},
+			want:   []want{{"rmrb", 3, Delete}},
+		},
+		{
+			name:   "派生文件计入总大小",
+			config: config.Retention{Rules: []config.RetentionRule{{MaxSize: "250B"}}},
+			files: []File{
+				with(ago("rmrb", 1), func(f *File) { f.Extra = []string{"rmrb_mobile.pdf"}; f.Size = 200 }),
+				ago("rmrb", 2),
+			},
+			want: []want{{"rmrb", 2, Delete}},
+		},
+		{
+			name:   "N天后只保留头版",
+			config: config.Retention{Rules: []config.RetentionRule{{FrontPageAfterDays: 10}}},
+			files: []File{
+				ago("rmrb", 9),
+				ago("rmrb", 10),
+				with(ago("rmrb", 11), func(f *File) { f.FrontPage = true }),
+				with(ago("rmrb", 12), func(f *File) { f.FrontPage = true; f.Extra = []string{"rmrb_mobile.pdf"} }),
+				with(ago("rmrb", 13), func(f *File) { f.Path = ""; f.Extra = []string{"rmrb_p1-4.pdf"} }),
+			},
+			want: []want{{"rmrb", 13, Delete}, {"rmrb", 12, FrontPage}, {"rmrb", 10, FrontPage}},
+		},
+		{
+			name:   "合集不保留头版",
+			config: config.Retention{Rules: []config.RetentionRule{{FrontPageAfterDays: 10}}},
+			files:  []File{with(ago("papers", 10), func(f *File) { f.Collection = true })},
+			want:   []want{{"papers", 10, Delete}},
+		},
+		{
+			name: "使用第一条匹配的规则",
+			config: config.Retention{Rules: []config.RetentionRule{
+				{Papers: []string{"xawb"}, KeepDays: 5},
+				{Family: "people", KeepDays: 10},
+				{KeepDays: 20},
+			}},
+			files: []File{
+				ago("xawb", 5),
+				with(ago("rmrb", 5), func(f *File) { f.Family = "people" }),
+				with(ago("rmrb", 10), func(f *File) { f.Family = "people" }),
+				ago("fcyym", 10),
+				with(ago("papers", 10), func(f *File) { f.Collection = true }),
+				with(ago("papers", 20), func(f *File) { f.Collection = true }),
+			},
+			want: []want{{"papers", 20, Delete}, {"rmrb", 10, Delete}, {"xawb", 5, Delete}},
+		},
+		{
+			name: "永不清理",
+			config: config.Retention{
+				Pins:  []string{"fcyym", "rmrb/2025-10-01"},
+				Rules: []config.RetentionRule{{KeepDays: 1}},
+			},
+			files: []File{ago("fcyym", 40), ago("rmrb", 40), ago("rmrb", 41)},
+			want:  []want{{"rmrb", 41, Delete}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPolicy(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := p.Plan(tt.files, today)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Plan() = %+v, want %+v", got, tt.want)
+			}
+			for i, d := range got {
+				w := tt.want[i]
+				if d.File.Paper != w.paper || days(d.File.Date, today) != w.age || d.Action != w.action {
+					t.Errorf("Plan()[%d] = %s %d天前 %v, want %s %d天前 %v", i, d.File.Paper, days(d.File.Date, today), d.Action, w.paper, w.age, w.action)
+				}
+			}
+		})
+	}
+}
+
+func TestNewPolicyErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		rule config.RetentionRule
+	}{
+		{"星期无效", config.RetentionRule{Weekdays: []string{"周八"}}},
+		{"大小无效", config.RetentionRule{MaxSize: "20G"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPolicy(config.Retention{Rules: []config.RetentionRule{tt.rule}}); err == nil {
+				t.Error("NewPolicy() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "500MB", want: 500 << 20},
+		{in: "1.5gb", want: 3 << 29},
+		{in: " 10 GB ", want: 10 << 30},
+		{in: "2TB", want: 2 << 40},
+		{in: "100B", want: 100},
+		{in: "20G", wantErr: true},
+		{in: "-1GB", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseSize(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSize(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSize(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"papers/internal/config"
+)
+
+// maxSleep 两次检查之间的最长等待时间，避免系统时间调整后错过任务
+const maxSleep = time.Hour
+
+// maxAttempts 同一计划时间最多执行的次数，执行失败（如报纸尚未发布、网络故障）时按退避时间重试
+const maxAttempts = 4
+
+// retryDelay 第一次重试前的等待时间，之后每次翻倍
+const retryDelay = 15 * time.Minute
+
+// Runner 执行一次定时任务，date 为该次任务对应的报纸日期
+// ctx 被取消时应在当前报纸完成后尽快返回
+type Runner func(ctx context.Context, job config.Job, date time.Time) error
+
+// Scheduler 定时下载任务调度器
+type Scheduler struct {
+	jobs        []*job
+	state       *State
+	statePath   string
+	catchUpDays int
+	run         Runner
+	now         func() time.Time // 当前时间，测试时可替换
+}
+
+// job 解析后的定时任务
+type job struct {
+	config.Job
+	loc      *time.Location
+	hour     int
+	minute   int
+	weekdays map[time.Weekday]bool // 为空表示每天
+}
+
+// New 根据配置创建调度器
+func New(cfg *config.Config, run Runner) (*Scheduler, error) {
+	if len(cfg.Schedule.Jobs) == 0 {
+		return nil, fmt.Errorf("配置文件中没有定时任务")
+	}
+
+	s := &Scheduler{
+		statePath:   cfg.StatePath("schedule_state.json"),
+		catchUpDays: cfg.Schedule.CatchUpDays,
+		run:         run,
+		now:         time.Now,
+	}
+
+	names := make(map[string]bool)
+	for _, jc := range cfg.Schedule.Jobs {
+		j, err := parseJob(jc)
+		if err != nil {
+			return nil, err
+		}
+		if names[j.Name] {
+			return nil, fmt.Errorf("定时任务 %s 重复", j.Name)
+		}
+		names[j.Name] = true
+		s.jobs = append(s.jobs, j)
+	}
+
+	state, err := LoadState(s.statePath)
+	if err != nil {
+		return nil, err
+	}
+	s.state = state
+
+	return s, nil
+}
+
+// parseJob 校验并解析定时任务配置
+func parseJob(jc config.Job) (*job, error) {
+	if jc.Name == "" {
+		jc.Name = strings.Join(jc.Papers, ",")
+	}
+	if len(jc.Papers) == 0 {
+		return nil, fmt.Errorf("定时任务 %s 没有指定报纸", jc.Name)
+	}
+
+	loc, err := time.LoadLocation(jc.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("定时任务 %s 的时区无效: %v", jc.Name, err)
+	}
+
+	at, err := time.Parse("15:04", jc.At)
+	if err != nil {
+		return nil, fmt.Errorf("定时任务 %s 的执行时间无效，应为 HH:MM 格式: %v", jc.Name, err)
+	}
+
+	j := &job{Job: jc, loc: loc, hour: at.Hour(), minute: at.Minute()}
+	if len(jc.Weekdays) > 0 {
+		j.weekdays = make(map[time.Weekday]bool)
+		for _, w := range jc.Weekdays {
+			d, err := config.ParseWeekday(w)
+			if err != nil {
+				return nil, fmt.Errorf("定时任务 %s: %v", jc.Name, err)
+			}
+			j.weekdays[d] = true
+		}
+	}
+
+	return j, nil
+}
+
+// occurrences 返回 (after, until] 区间内任务的所有执行时间，按时间先后排列
+func (j *job) occurrences(after, until time.Time) []time.Time {
+	var times []time.Time
+	day := after.In(j.loc)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, j.loc)
+	for !day.After(until) {
+		t := time.Date(day.Year(), day.Month(), day.Day(), j.hour, j.minute, 0, 0, j.loc)
+		if t.After(after) && !t.After(until) && (j.weekdays == nil || j.weekdays[t.Weekday()]) {
+			times = append(times, t)
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return times
+}
+
+// next 返回 after 之后任务的下一次执行时间
+func (j *job) next(after time.Time) time.Time {
+	// 一周内必然有一次执行
+	times := j.occurrences(after, after.AddDate(0, 0, 8))
+	return times[0]
+}
+
+// String 返回任务的描述
+func (j *job) String() string {
+	days := "每天"
+	if j.weekdays != nil {
+		days = "每周" + strings.Join(j.Weekdays, ",")
+	}
+	return fmt.Sprintf("%s [%s] %s %02d:%02d %s", j.Name, strings.Join(j.Papers, ","), days, j.hour, j.minute, j.loc)
+}
+
+// Run 运行调度器，直到 ctx 被取消
+// 启动时会补跑停机期间错过的任务
+func (s *Scheduler) Run(ctx context.Context) error {
+	fmt.Println("已加载定时任务:")
+	for _, j := range s.jobs {
+		fmt.Printf("  • %s\n", j)
+	}
+	fmt.Println()
+
+	for {
+		if err := s.runDue(ctx); err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			fmt.Println("调度器已停止")
+			return nil
+		}
+
+		j, next := s.nextRun(s.now())
+		fmt.Printf("下一次任务: %s 于 %s\n", j.Name, next.Format("2006-01-02 15:04 MST"))
+
+		wait := next.Sub(s.now())
+		if wait > maxSleep {
+			wait = maxSleep
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			fmt.Println("调度器已停止")
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// pending 一次待执行的任务
+type pending struct {
+	job   *job
+	at    time.Time
+	retry bool // 之前执行失败的重试
+}
+
+// runDue 执行所有已到期但尚未执行的任务，包括停机期间错过的任务和到期的重试
+func (s *Scheduler) runDue(ctx context.Context) error {
+	now := s.now()
+	window := now.AddDate(0, 0, -s.catchUpDays)
+
+	var due []pending
+	for _, j := range s.jobs {
+		// Drop 会原地改写重试列表，遍历副本
+		for _, r := range append([]Retry(nil), s.state.Retries(j.Name)...) {
+			if r.At.Before(window) {
+				// 超出补跑范围的重试不再执行
+				s.state.Drop(j.Name, r.At)
+				continue
+			}
+			if !r.Next.After(now) {
+				due = append(due, pending{job: j, at: r.At, retry: true})
+			}
+		}
+
+		last, ok := s.state.LastRun(j.Name)
+		if !ok {
+			// 首次运行的任务只补跑最近一次
+			if times := j.occurrences(window, now); len(times) > 0 {
+				due = append(due, pending{job: j, at: times[len(times)-1]})
+			}
+			continue
+		}
+
+		after := last
+		if after.Before(window) {
+			after = window
+		}
+		for _, t := range j.occurrences(after, now) {
+			due = append(due, pending{job: j, at: t})
+		}
+	}
+
+	sort.SliceStable(due, func(a, b int) bool { return due[a].at.Before(due[b].at) })
+
+	for _, p := range due {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		attempts := s.state.Attempts(p.job.Name, p.at) + 1
+		switch {
+		case p.retry:
+			fmt.Printf("=== 重试定时任务 %s (%s，第 %d 次) ===\n", p.job.Name, p.at.Format("2006-01-02 15:04"), attempts)
+		case now.Sub(p.at) > time.Minute:
+			fmt.Printf("=== 补跑错过的任务 %s (%s) ===\n", p.job.Name, p.at.Format("2006-01-02 15:04"))
+		default:
+			fmt.Printf("=== 执行定时任务 %s ===\n", p.job.Name)
+		}
+
+		err := s.run(ctx, p.job.Job, p.at)
+		if errors.Is(err, context.Canceled) || ctx.Err() != nil {
+			// 任务被中断，不记录状态，下次启动时补跑
+			return nil
+		}
+
+		var next time.Time
+		if err != nil {
+			fmt.Printf("定时任务 %s 执行失败: %v\n", p.job.Name, err)
+			if attempts < maxAttempts {
+				next = s.now().Add(retryBackoff(attempts))
+				fmt.Printf("将于 %s 重试\n", next.Format("2006-01-02 15:04"))
+			} else {
+				fmt.Printf("已执行 %d 次，不再重试\n", attempts)
+			}
+		}
+
+		s.state.Record(p.job.Name, p.at, err, next)
+		if err := s.state.Save(s.statePath); err != nil {
+			return fmt.Errorf("保存调度状态失败: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// retryBackoff 返回第 attempts 次执行失败后距下一次重试的等待时间
+func retryBackoff(attempts int) time.Duration {
+	return retryDelay << (attempts - 1)
+}
+
+// nextRun 返回最早需要执行的任务及其时间，包括等待中的重试
+func (s *Scheduler) nextRun(now time.Time) (*job, time.Time) {
+	var first *job
+	var at time.Time
+	for _, j := range s.jobs {
+		t := j.next(now)
+		for _, r := range s.state.Retries(j.Name) {
+			if r.Next.Before(t) {
+				t = r.Next
+			}
+		}
+		if first == nil || t.Before(at) {
+			first, at = j, t
+		}
+	}
+	return first, at
+}
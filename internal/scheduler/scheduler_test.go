@@ -0,0 +1,340 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"papers/internal/config"
+)
+
+var shanghai, _ = time.LoadLocation("Asia/Shanghai")
+
+// at 返回东8区的时间
+func at(day, hour, minute int) time.Time {
+	return time.Date(2025, 11, day, hour, minute, 0, 0, shanghai)
+}
+
+func mustJob(t *testing.T, jc config.Job) *job {
+	t.Helper()
+	if jc.Timezone == "" {
+		jc.Timezone = "Asia/Shanghai"
+	}
+	j, err := parseJob(jc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return j
+}
+
+func TestParseJob(t *testing.T) {
+	tests := []struct {
+		name    string
+		job     config.Job
+		wantErr bool
+	}{
+		{"有效", config.Job{Name: "daily", Papers: []string{"rmrb"}, At: "07:00", Timezone: "Asia/Shanghai"}, false},
+		{"没有报纸", config.Job{Name: "daily", At: "07:00", Timezone: "Asia/Shanghai"}, true},
+		{"时间无效", config.Job{Papers: []string{"rmrb"}, At: "7点", Timezone: "Asia/Shanghai"}, true},
+		{"时区无效", config.Job{Papers: []string{"rmrb"}, At: "07:00", Timezone: "Mars/Base"}, true},
+		{"星期无效", config.Job{Papers: []string{"rmrb"}, At: "07:00", Timezone: "UTC", Weekdays: []string{"someday"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseJob(tt.job); (err != nil) != tt.wantErr {
+				t.Errorf("parseJob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	// 2025-11-10 为星期一
+	tests := []struct {
+		name         string
+		job          config.Job
+		after, until time.Time
+		want         []time.Time
+	}{
+		{
+			name:  "每天",
+			job:   config.Job{Papers: []string{"rmrb"}, At: "07:00"},
+			after: at(10, 8, 0), until: at(12, 23, 0),
+			want: []time.Time{at(11, 7, 0), at(12, 7, 0)},
+		},
+		{
+			name:  "不包含 after，包含 until",
+			job:   config.Job{Papers: []string{"rmrb"}, At: "07:00"},
+			after: at(10, 7, 0), until: at(11, 7, 0),
+			want: []time.Time{at(11, 7, 0)},
+		},
+		{
+			name:  "指定星期",
+			job:   config.Job{Papers: []string{"fcyym"}, At: "09:00", Weekdays: []string{"fri"}},
+			after: at(10, 0, 0), until: at(24, 0, 0),
+			want: []time.Time{at(14, 9, 0), at(21, 9, 0)},
+		},
+		{
+			name:  "区间内没有执行",
+			job:   config.Job{Papers: []string{"rmrb"}, At: "07:00"},
+			after: at(10, 8, 0), until: at(11, 6, 59),
+			want: nil,
+		},
+		{
+			name:  "其他时区",
+			job:   config.Job{Papers: []string{"rmrb"}, At: "07:00", Timezone: "UTC"},
+			after: at(10, 8, 0), until: at(11, 8, 0),
+			want: []time.Time{at(10, 15, 0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mustJob(t, tt.job).occurrences(tt.after, tt.until)
+			if len(got) != len(tt.want) {
+				t.Fatalf("occurrences() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrences()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// fakeRunner 记录每次执行的计划时间，按顺序返回预设的结果
+type fakeRunner struct {
+	runs    []time.Time
+	results []error
+}
+
+func (f *fakeRunner) run(ctx context.Context, job config.Job, date time.Time) error {
+	f.runs = append(f.runs, date)
+	if len(f.results) == 0 {
+		return nil
+	}
+	err := f.results[0]
+	f.results = f.results[1:]
+	return err
+}
+
+func newTestScheduler(t *testing.T, jc config.Job, state *State, run *fakeRunner) (*Scheduler, *time.Time) {
+	t.Helper()
+	now := at(10, 0, 0)
+	if state == nil {
+		state = &State{Jobs: make(map[string]*JobState)}
+	}
+	s := &Scheduler{
+		jobs:        []*job{mustJob(t, jc)},
+		state:       state,
+		statePath:   filepath.Join(t.TempDir(), "schedule_state.json"),
+		catchUpDays: 7,
+		run:         run.run,
+		now:         func() time.Time { return now },
+	}
+	return s, &now
+}
+
+func TestRunDueCatchUp(t *testing.T) {
+	daily := config.Job{Name: "daily", Papers: []string{"rmrb"}, At: "07:00"}
+
+	tests := []struct {
+		name    string
+		lastRun *time.Time
+		now     time.Time
+		want    []time.Time
+	}{
+		{
+			name: "首次运行只补跑最近一次",
+			now:  at(12, 8, 0),
+			want: []time.Time{at(12, 7, 0)},
+		},
+		{
+			name:    "补跑停机期间错过的任务",
+			lastRun: ptr(at(9, 7, 0)),
+			now:     at(12, 8, 0),
+			want:    []time.Time{at(10, 7, 0), at(11, 7, 0), at(12, 7, 0)},
+		},
+		{
+			name:    "最多补跑 catch_up_days 天",
+			lastRun: ptr(at(1, 7, 0)),
+			now:     at(12, 8, 0),
+			want:    []time.Time{at(6, 7, 0), at(7, 7, 0), at(8, 7, 0), at(9, 7, 0), at(10, 7, 0), at(11, 7, 0), at(12, 7, 0)},
+		},
+		{
+			name:    "已执行的不再执行",
+			lastRun: ptr(at(12, 7, 0)),
+			now:     at(12, 8, 0),
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &State{Jobs: make(map[string]*JobState)}
+			if tt.lastRun != nil {
+				state.Jobs["daily"] = &JobState{LastRun: *tt.lastRun}
+			}
+			run := &fakeRunner{}
+			s, now := newTestScheduler(t, daily, state, run)
+			*now = tt.now
+
+			if err := s.runDue(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if len(run.runs) != len(tt.want) {
+				t.Fatalf("runs = %v, want %v", run.runs, tt.want)
+			}
+			for i := range run.runs {
+				if !run.runs[i].Equal(tt.want[i]) {
+					t.Errorf("runs[%d] = %v, want %v", i, run.runs[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRunDueRetry(t *testing.T) {
+	daily := config.Job{Name: "daily", Papers: []string{"rmrb"}, At: "07:00"}
+	errNotPublished := errors.New("尚未发布")
+
+	t.Run("失败后按退避时间重试直到成功", func(t *testing.T) {
+		run := &fakeRunner{results: []error{errNotPublished, errNotPublished, nil}}
+		s, now := newTestScheduler(t, daily, nil, run)
+
+		steps := []struct {
+			now       time.Time
+			wantRuns  int
+			wantRetry bool
+		}{
+			{at(10, 7, 0), 1, true},   // 第一次执行失败，15分钟后重试
+			{at(10, 7, 10), 1, true},  // 未到重试时间
+			{at(10, 7, 15), 2, true},  // 第二次执行失败，30分钟后重试
+			{at(10, 7, 44), 2, true},  // 未到重试时间
+			{at(10, 7, 45), 3, false}, // 第三次执行成功
+			{at(10, 9, 0), 3, false},  // 不再执行
+		}
+		for _, step := range steps {
+			*now = step.now
+			if err := s.runDue(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if len(run.runs) != step.wantRuns {
+				t.Fatalf("%s: runs = %d, want %d", step.now.Format("15:04"), len(run.runs), step.wantRuns)
+			}
+			if got := len(s.state.Retries("daily")) > 0; got != step.wantRetry {
+				t.Fatalf("%s: retry pending = %v, want %v", step.now.Format("15:04"), got, step.wantRetry)
+			}
+		}
+		for _, r := range run.runs {
+			if !r.Equal(at(10, 7, 0)) {
+				t.Errorf("run for %v, want the 07:00 occurrence", r)
+			}
+		}
+	})
+
+	t.Run("最多执行 maxAttempts 次", func(t *testing.T) {
+		run := &fakeRunner{results: []error{errNotPublished, errNotPublished, errNotPublished, errNotPublished, errNotPublished}}
+		s, now := newTestScheduler(t, daily, nil, run)
+
+		*now = at(10, 7, 0)
+		for i := 0; i < 10; i++ {
+			if err := s.runDue(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			*now = now.Add(2 * time.Hour)
+			if now.After(at(10, 23, 0)) {
+				break
+			}
+		}
+		if len(run.runs) != maxAttempts {
+			t.Errorf("runs = %d, want %d", len(run.runs), maxAttempts)
+		}
+		if r := s.state.Retries("daily"); len(r) != 0 {
+			t.Errorf("retries = %v, want none", r)
+		}
+	})
+
+	t.Run("重试记录在重启后保留", func(t *testing.T) {
+		run := &fakeRunner{results: []error{errNotPublished}}
+		s, now := newTestScheduler(t, daily, nil, run)
+		*now = at(10, 7, 0)
+		if err := s.runDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		state, err := LoadState(s.statePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		restarted, now2 := newTestScheduler(t, daily, state, run)
+		restarted.statePath = s.statePath
+		*now2 = at(10, 8, 0)
+
+		j, next := restarted.nextRun(at(10, 7, 5))
+		if j.Name != "daily" || !next.Equal(at(10, 7, 15)) {
+			t.Errorf("nextRun() = %s %v, want daily %v", j.Name, next, at(10, 7, 15))
+		}
+
+		if err := restarted.runDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if len(run.runs) != 2 || !run.runs[1].Equal(at(10, 7, 0)) {
+			t.Errorf("runs = %v, want the 07:00 occurrence retried", run.runs)
+		}
+		if r := restarted.state.Retries("daily"); len(r) != 0 {
+			t.Errorf("retries = %v, want none", r)
+		}
+	})
+
+	t.Run("丢弃过期重试后执行其余重试", func(t *testing.T) {
+		state := &State{Jobs: map[string]*JobState{
+			"daily": {
+				LastRun: at(10, 7, 0),
+				Retries: []Retry{
+					{At: at(1, 7, 0), Attempts: 1, Next: at(1, 7, 15)}, // 超出补跑范围
+					{At: at(9, 7, 0), Attempts: 1, Next: at(9, 7, 15)},
+					{At: at(10, 7, 0), Attempts: 1, Next: at(10, 7, 15)},
+				},
+			},
+		}}
+		run := &fakeRunner{}
+		s, now := newTestScheduler(t, daily, state, run)
+		*now = at(10, 8, 0)
+		if err := s.runDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		want := []time.Time{at(9, 7, 0), at(10, 7, 0)}
+		if len(run.runs) != len(want) {
+			t.Fatalf("runs = %v, want %v", run.runs, want)
+		}
+		for i := range want {
+			if !run.runs[i].Equal(want[i]) {
+				t.Errorf("run %d = %v, want %v", i, run.runs[i], want[i])
+			}
+		}
+		if r := s.state.Retries("daily"); len(r) != 0 {
+			t.Errorf("retries = %v, want none", r)
+		}
+	})
+
+	t.Run("被中断的任务不记录", func(t *testing.T) {
+		run := &fakeRunner{results: []error{context.Canceled}}
+		s, now := newTestScheduler(t, daily, nil, run)
+		*now = at(10, 7, 0)
+		if err := s.runDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := s.state.LastRun("daily"); ok {
+			t.Error("canceled run was recorded")
+		}
+	})
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,137 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// State 调度器的持久化状态，记录每个任务最近一次执行
+type State struct {
+	Jobs map[string]*JobState `json:"jobs"`
+}
+
+// JobState 单个任务的执行记录
+type JobState struct {
+	LastRun    time.Time `json:"last_run"`             // 最近一次执行对应的计划时间
+	FinishedAt time.Time `json:"finished_at"`          // 实际完成时间
+	LastError  string    `json:"last_error,omitempty"` // 最近一次执行的错误
+	Retries    []Retry   `json:"retries,omitempty"`    // 执行失败、等待重试的计划时间
+}
+
+// Retry 一次执行失败、等待重试的任务
+type Retry struct {
+	At       time.Time `json:"at"`       // 计划时间
+	Attempts int       `json:"attempts"` // 已执行的次数
+	Next     time.Time `json:"next"`     // 下一次重试的时间
+}
+
+// LoadState 读取状态文件，文件不存在时返回空状态
+func LoadState(path string) (*State, error) {
+	state := &State{Jobs: make(map[string]*JobState)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取调度状态失败: %v", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("解析调度状态 %s 失败: %v", path, err)
+	}
+	if state.Jobs == nil {
+		state.Jobs = make(map[string]*JobState)
+	}
+	return state, nil
+}
+
+// LastRun 返回任务最近一次执行的计划时间
+func (s *State) LastRun(name string) (time.Time, bool) {
+	js, ok := s.Jobs[name]
+	if !ok {
+		return time.Time{}, false
+	}
+	return js.LastRun, true
+}
+
+// Retries 返回任务等待重试的计划时间
+func (s *State) Retries(name string) []Retry {
+	if js, ok := s.Jobs[name]; ok {
+		return js.Retries
+	}
+	return nil
+}
+
+// Attempts 返回任务在计划时间 at 已执行的次数
+func (s *State) Attempts(name string, at time.Time) int {
+	for _, r := range s.Retries(name) {
+		if r.At.Equal(at) {
+			return r.Attempts
+		}
+	}
+	return 0
+}
+
+// Record 记录任务在计划时间 at 的一次执行
+// 执行失败且 next 不为零值时保留重试记录，在 next 之后重试；否则移除该计划时间的重试记录
+func (s *State) Record(name string, at time.Time, err error, next time.Time) {
+	js, ok := s.Jobs[name]
+	if !ok {
+		js = &JobState{}
+		s.Jobs[name] = js
+	}
+	if at.After(js.LastRun) {
+		js.LastRun = at
+	}
+	js.FinishedAt = time.Now()
+	js.LastError = ""
+	if err != nil {
+		js.LastError = err.Error()
+	}
+
+	attempts := s.Attempts(name, at) + 1
+	s.Drop(name, at)
+	if err != nil && !next.IsZero() {
+		js.Retries = append(js.Retries, Retry{At: at, Attempts: attempts, Next: next})
+	}
+}
+
+// Drop 移除任务在计划时间 at 的重试记录
+func (s *State) Drop(name string, at time.Time) {
+	js, ok := s.Jobs[name]
+	if !ok {
+		return
+	}
+	retries := js.Retries[:0]
+	for _, r := range js.Retries {
+		if !r.At.Equal(at) {
+			retries = append(retries, r)
+		}
+	}
+	if len(retries) == 0 {
+		retries = nil
+	}
+	js.Retries = retries
+}
+
+// Save 写入状态文件，先写临时文件再重命名，避免中断时损坏
+func (s *State) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}